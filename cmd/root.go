@@ -8,13 +8,16 @@ import (
 	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
 	"github.com/r4g3baby/mcserver/pkg/server"
 	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"github.com/r4g3baby/mcserver/pkg/util/terminal"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
+	"io"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 )
 
@@ -29,7 +32,15 @@ var rootCmd = &cobra.Command{
 	Version: Version,
 	Run: func(cmd *cobra.Command, args []string) {
 		var config = setupConfig()
-		setupLogger(config)
+
+		// Log output goes through the terminal so it doesn't break up the command being typed
+		var input io.Reader = os.Stdin
+		var output io.Writer = os.Stderr
+		if term, err := terminal.NewTerminal(os.Stdin, os.Stderr, "> "); err == nil {
+			defer func() { _ = term.Close() }()
+			input, output = term, term
+		}
+		setupLogger(config, output)
 
 		serv := server.NewServer(config.Server)
 		if err := serv.Start(); err != nil {
@@ -50,52 +61,58 @@ var rootCmd = &cobra.Command{
 		world.SetBlock(1, 64, -1, "minecraft:stone")
 
 		_ = serv.OnAsync(server.OnPacketReadEvent, func(e server.PacketEvent) {
-			if chatPacket, ok := e.GetPacket().(*packets.PacketPlayInChatMessage); ok {
-				serv.ForEachPlayer(func(player server.Player) bool {
-					_ = player.SendPacket(&packets.PacketPlayOutChatMessage{
-						Message: []chat.Component{
-							&chat.TranslatableComponent{
-								Translate: "chat.type.text",
-								With: []chat.Component{
-									&chat.TextComponent{
-										Text: player.GetUsername(),
-										BaseComponent: chat.BaseComponent{
-											ClickEvent: &chat.ClickEvent{
-												Action: chat.SuggestCommandClickAction,
-												Value:  "/tell " + player.GetUsername(),
-											},
-											HoverEvent: &chat.HoverEvent{
-												Action: chat.ShowEntityHoverAction,
-												Contents: fmt.Sprintf(
-													"{id:%s,type:minecraft:player,name:%s}",
-													player.GetUniqueID(), player.GetUsername(),
-												),
-											},
-											Insertion: player.GetUsername(),
+			if chatPacket, ok := e.GetPacket().(*packets.PacketPlayInChatMessage); ok && e.GetPlayer() != nil && !strings.HasPrefix(chatPacket.Message, "/") {
+				player := e.GetPlayer()
+				serv.Broadcast(&packets.PacketPlayOutChatMessage{
+					Message: []chat.Component{
+						&chat.TranslatableComponent{
+							Translate: "chat.type.text",
+							With: []chat.Component{
+								&chat.TextComponent{
+									Text: player.GetUsername(),
+									BaseComponent: chat.BaseComponent{
+										ClickEvent: &chat.ClickEvent{
+											Action: chat.SuggestCommandClickAction,
+											Value:  "/tell " + player.GetUsername(),
 										},
+										HoverEvent: &chat.HoverEvent{
+											Action: chat.ShowEntityHoverAction,
+											Contents: fmt.Sprintf(
+												"{id:%s,type:minecraft:player,name:%s}",
+												player.GetUniqueID(), player.GetUsername(),
+											),
+										},
+										Insertion: player.GetUsername(),
 									},
-									&chat.TextComponent{
-										Text: chatPacket.Message,
-									},
+								},
+								&chat.TextComponent{
+									Text: chatPacket.Message,
 								},
 							},
 						},
-						Position: 0,
-						Sender:   player.GetUniqueID(),
-					})
-					return true
-				})
+					},
+					Position: 0,
+					Sender:   player.GetUniqueID(),
+				}, nil)
 			}
 		})
 
+		go func() {
+			if err := server.NewConsole(serv).Run(input); err != nil {
+				log.Log.Error(err, "failed to read console input")
+			}
+		}()
+
 		shutdownSignal := make(chan os.Signal, 1)
 		signal.Notify(shutdownSignal, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, os.Interrupt)
-		sig := <-shutdownSignal
-
-		log.Log.V(1).Info("received shutdown signal", "signal", sig)
-		if err := serv.Stop(); err != nil {
-			log.Log.Error(err, "failed to stop server")
-			os.Exit(1)
+		select {
+		case sig := <-shutdownSignal:
+			log.Log.V(1).Info("received shutdown signal", "signal", sig)
+			if err := serv.Stop(); err != nil {
+				log.Log.Error(err, "failed to stop server")
+				os.Exit(1)
+			}
+		case <-serv.Done():
 		}
 	},
 }
@@ -132,7 +149,7 @@ func setupConfig() config.Config {
 	return conf
 }
 
-func setupLogger(config config.Config) {
+func setupLogger(config config.Config, output io.Writer) {
 	var zapConfig = zap.NewProductionEncoderConfig()
 	var level = zap.InfoLevel
 	if config.Debug {
@@ -159,10 +176,10 @@ func setupLogger(config config.Config) {
 				LocalTime:  config.Logger.LocalTime,
 				Compress:   config.Logger.Compress,
 			}), level),
-			zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stderr), level),
+			zapcore.NewCore(consoleEncoder, zapcore.AddSync(output), level),
 		)
 	} else {
-		core = zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stderr), level)
+		core = zapcore.NewCore(consoleEncoder, zapcore.AddSync(output), level)
 	}
 
 	log.SetLogger(zapr.NewLogger(zap.New(core, zap.WithCaller(false))))
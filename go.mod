@@ -6,10 +6,11 @@ require (
 	github.com/go-logr/logr v0.4.0
 	github.com/go-logr/zapr v0.4.0
 	github.com/google/uuid v1.2.0
-	github.com/imdario/mergo v0.3.12
 	github.com/klauspost/compress v1.12.3
+	github.com/prometheus/client_golang v1.11.1
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/viper v1.7.1
 	go.uber.org/zap v1.17.0
+	golang.org/x/sys v0.0.0-20210603081109-ebe580a85c40
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0
 )
@@ -0,0 +1,202 @@
+package command
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"math"
+)
+
+type (
+	// ArgumentType parses an argument value and describes it to the client
+	ArgumentType interface {
+		Parse(reader *StringReader) (interface{}, error)
+		GetParser() string
+		WriteProperties(buffer *bytes.Buffer) error
+	}
+
+	StringMode int32
+)
+
+const (
+	SingleWord StringMode = iota
+	QuotablePhrase
+	GreedyPhrase
+)
+
+type (
+	boolArgument    struct{}
+	integerArgument struct{ min, max int32 }
+	doubleArgument  struct{ min, max float64 }
+	stringArgument  struct{ mode StringMode }
+)
+
+func (argument *boolArgument) Parse(reader *StringReader) (interface{}, error) {
+	return reader.ReadBool()
+}
+
+func (argument *boolArgument) GetParser() string {
+	return "brigadier:bool"
+}
+
+func (argument *boolArgument) WriteProperties(_ *bytes.Buffer) error {
+	return nil
+}
+
+func (argument *integerArgument) Parse(reader *StringReader) (interface{}, error) {
+	start := reader.GetCursor()
+	value, err := reader.ReadInt()
+	if err != nil {
+		return nil, err
+	}
+
+	if value < argument.min {
+		reader.SetCursor(start)
+		return nil, reader.error("Integer must not be less than %d, found %d", argument.min, value)
+	} else if value > argument.max {
+		reader.SetCursor(start)
+		return nil, reader.error("Integer must not be more than %d, found %d", argument.max, value)
+	}
+	return value, nil
+}
+
+func (argument *integerArgument) GetParser() string {
+	return "brigadier:integer"
+}
+
+func (argument *integerArgument) WriteProperties(buffer *bytes.Buffer) error {
+	var flags uint8
+	if argument.min != math.MinInt32 {
+		flags |= 0x01
+	}
+	if argument.max != math.MaxInt32 {
+		flags |= 0x02
+	}
+
+	if err := buffer.WriteUint8(flags); err != nil {
+		return err
+	}
+
+	if flags&0x01 != 0 {
+		if err := buffer.WriteInt32(argument.min); err != nil {
+			return err
+		}
+	}
+
+	if flags&0x02 != 0 {
+		if err := buffer.WriteInt32(argument.max); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (argument *doubleArgument) Parse(reader *StringReader) (interface{}, error) {
+	start := reader.GetCursor()
+	value, err := reader.ReadDouble()
+	if err != nil {
+		return nil, err
+	}
+
+	if value < argument.min {
+		reader.SetCursor(start)
+		return nil, reader.error("Double must not be less than %v, found %v", argument.min, value)
+	} else if value > argument.max {
+		reader.SetCursor(start)
+		return nil, reader.error("Double must not be more than %v, found %v", argument.max, value)
+	}
+	return value, nil
+}
+
+func (argument *doubleArgument) GetParser() string {
+	return "brigadier:double"
+}
+
+func (argument *doubleArgument) WriteProperties(buffer *bytes.Buffer) error {
+	var flags uint8
+	if argument.min != -math.MaxFloat64 {
+		flags |= 0x01
+	}
+	if argument.max != math.MaxFloat64 {
+		flags |= 0x02
+	}
+
+	if err := buffer.WriteUint8(flags); err != nil {
+		return err
+	}
+
+	if flags&0x01 != 0 {
+		if err := buffer.WriteFloat64(argument.min); err != nil {
+			return err
+		}
+	}
+
+	if flags&0x02 != 0 {
+		if err := buffer.WriteFloat64(argument.max); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (argument *stringArgument) Parse(reader *StringReader) (interface{}, error) {
+	switch argument.mode {
+	case GreedyPhrase:
+		value := reader.GetRemaining()
+		reader.SetCursor(len(reader.GetInput()))
+		return value, nil
+	case QuotablePhrase:
+		start := reader.GetCursor()
+		value, err := reader.ReadString()
+		if err != nil {
+			return nil, err
+		}
+		if reader.GetCursor() == start {
+			return nil, reader.error("Expected string")
+		}
+		return value, nil
+	default:
+		value := reader.ReadUnquotedString()
+		if value == "" {
+			return nil, reader.error("Expected string")
+		}
+		return value, nil
+	}
+}
+
+func (argument *stringArgument) GetParser() string {
+	return "brigadier:string"
+}
+
+func (argument *stringArgument) WriteProperties(buffer *bytes.Buffer) error {
+	return buffer.WriteVarInt(int32(argument.mode))
+}
+
+func Bool() ArgumentType {
+	return &boolArgument{}
+}
+
+// Integer accepts whole numbers between min and max, use math.MinInt32 and math.MaxInt32 for no bounds.
+func Integer(min, max int32) ArgumentType {
+	return &integerArgument{min: min, max: max}
+}
+
+// Double accepts numbers between min and max, use -math.MaxFloat64 and math.MaxFloat64 for no bounds.
+func Double(min, max float64) ArgumentType {
+	return &doubleArgument{min: min, max: max}
+}
+
+// Word accepts a single unquoted word
+func Word() ArgumentType {
+	return &stringArgument{mode: SingleWord}
+}
+
+// String accepts a single word or a quoted phrase
+func String() ArgumentType {
+	return &stringArgument{mode: QuotablePhrase}
+}
+
+// GreedyString accepts everything until the end of the input
+func GreedyString() ArgumentType {
+	return &stringArgument{mode: GreedyPhrase}
+}
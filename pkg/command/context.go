@@ -0,0 +1,51 @@
+package command
+
+type Context struct {
+	source    Source
+	input     string
+	arguments map[string]interface{}
+}
+
+func (ctx *Context) GetSource() Source {
+	return ctx.source
+}
+
+func (ctx *Context) GetInput() string {
+	return ctx.input
+}
+
+func (ctx *Context) GetArgument(name string) interface{} {
+	return ctx.arguments[name]
+}
+
+func (ctx *Context) GetString(name string) string {
+	value, _ := ctx.arguments[name].(string)
+	return value
+}
+
+func (ctx *Context) GetInt(name string) int32 {
+	value, _ := ctx.arguments[name].(int32)
+	return value
+}
+
+func (ctx *Context) GetDouble(name string) float64 {
+	value, _ := ctx.arguments[name].(float64)
+	return value
+}
+
+func (ctx *Context) GetBool(name string) bool {
+	value, _ := ctx.arguments[name].(bool)
+	return value
+}
+
+func (ctx *Context) copy() *Context {
+	arguments := make(map[string]interface{}, len(ctx.arguments))
+	for name, value := range ctx.arguments {
+		arguments[name] = value
+	}
+	return &Context{source: ctx.source, input: ctx.input, arguments: arguments}
+}
+
+func newContext(source Source, input string) *Context {
+	return &Context{source: source, input: input, arguments: make(map[string]interface{})}
+}
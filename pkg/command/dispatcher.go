@@ -0,0 +1,193 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	Dispatcher interface {
+		Register(node *Node) error
+		Unregister(name string)
+		GetRoot() *Node
+		Execute(source Source, input string) error
+		Suggest(source Source, input string) (start int, suggestions []Suggestion)
+	}
+
+	dispatcher struct {
+		mutex sync.RWMutex
+		root  *Node
+	}
+)
+
+func (dispatcher *dispatcher) Register(node *Node) error {
+	if !node.IsLiteral() {
+		return fmt.Errorf("command %s must be a literal", node.name)
+	}
+
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	for _, child := range dispatcher.root.children {
+		if child.name == node.name {
+			return fmt.Errorf("command %s is already registered", node.name)
+		}
+	}
+
+	// The root is replaced instead of modified so readers never see a partial update
+	root := &Node{children: append([]*Node(nil), dispatcher.root.children...)}
+	dispatcher.root = root.Then(node)
+	return nil
+}
+
+func (dispatcher *dispatcher) Unregister(name string) {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	root := &Node{}
+	for _, child := range dispatcher.root.children {
+		if child.name != name {
+			root.children = append(root.children, child)
+		}
+	}
+	dispatcher.root = root
+}
+
+func (dispatcher *dispatcher) GetRoot() *Node {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	return dispatcher.root
+}
+
+func (dispatcher *dispatcher) Execute(source Source, input string) error {
+	reader := NewStringReader(input)
+	node, ctx, err := parse(dispatcher.GetRoot(), reader, newContext(source, input))
+	if err != nil {
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) && syntaxErr.Cursor == 0 {
+			syntaxErr.Message = "Unknown command"
+		}
+		return err
+	}
+
+	if node.command == nil {
+		return &SyntaxError{
+			Message: "Unknown or incomplete command",
+			Input:   input,
+			Cursor:  len(input),
+		}
+	}
+	return node.command(ctx)
+}
+
+func (dispatcher *dispatcher) Suggest(source Source, input string) (int, []Suggestion) {
+	start, suggestions := suggest(dispatcher.GetRoot(), NewStringReader(input), newContext(source, input))
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].Text < suggestions[j].Text
+	})
+	return start, suggestions
+}
+
+// parse walks the tree trying every usable child in order,
+// returning the first full match or the error that got the furthest.
+func parse(node *Node, reader *StringReader, ctx *Context) (*Node, *Context, error) {
+	var lastErr error
+	for _, child := range node.children {
+		if !child.CanUse(ctx.source) {
+			continue
+		}
+
+		childReader, childCtx := reader.clone(), ctx.copy()
+		if err := child.parse(childReader, childCtx); err != nil {
+			lastErr = furthestError(lastErr, err)
+			continue
+		}
+
+		if !childReader.CanRead() {
+			return child, childCtx, nil
+		}
+
+		if childReader.Peek() != ' ' {
+			lastErr = furthestError(lastErr, childReader.error("Expected whitespace to end one argument, but found trailing data"))
+			continue
+		}
+		childReader.Skip()
+
+		result, resultCtx, err := parse(child, childReader, childCtx)
+		if err != nil {
+			lastErr = furthestError(lastErr, err)
+			continue
+		}
+		return result, resultCtx, nil
+	}
+
+	if lastErr == nil {
+		lastErr = reader.error("Incorrect argument for command")
+	}
+	return nil, nil, lastErr
+}
+
+func suggest(node *Node, reader *StringReader, ctx *Context) (int, []Suggestion) {
+	start := reader.GetCursor()
+	remaining := reader.GetRemaining()
+
+	resultStart := start
+	var result []Suggestion
+	for _, child := range node.children {
+		if !child.CanUse(ctx.source) {
+			continue
+		}
+
+		childReader, childCtx := reader.clone(), ctx.copy()
+		err := child.parse(childReader, childCtx)
+		if err == nil && childReader.CanRead() {
+			if childReader.Peek() == ' ' {
+				childReader.Skip()
+				childStart, suggestions := suggest(child, childReader, childCtx)
+				if len(suggestions) > 0 && childStart > resultStart {
+					resultStart, result = childStart, nil
+				}
+				if childStart == resultStart {
+					result = append(result, suggestions...)
+				}
+			}
+			continue
+		}
+
+		// Only the last word is completed, unless the argument takes everything that's left
+		if resultStart != start || strings.Contains(remaining, " ") && !isGreedy(child) {
+			continue
+		}
+
+		for _, suggestion := range child.getSuggestions(childCtx, remaining) {
+			if strings.HasPrefix(strings.ToLower(suggestion.Text), strings.ToLower(remaining)) {
+				result = append(result, suggestion)
+			}
+		}
+	}
+	return resultStart, result
+}
+
+func isGreedy(node *Node) bool {
+	argument, ok := node.argument.(*stringArgument)
+	return ok && argument.mode == GreedyPhrase
+}
+
+func furthestError(current, err error) error {
+	var currentErr, syntaxErr *SyntaxError
+	if current == nil || !errors.As(current, &currentErr) {
+		return err
+	}
+
+	if errors.As(err, &syntaxErr) && syntaxErr.Cursor > currentErr.Cursor {
+		return err
+	}
+	return current
+}
+
+func NewDispatcher() Dispatcher {
+	return &dispatcher{root: &Node{}}
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"math"
+	"testing"
+)
+
+type testSource struct {
+	permissions map[string]bool
+}
+
+func (source *testSource) GetName() string {
+	return "test"
+}
+
+func (source *testSource) SendMessage(_ []chat.Component) error {
+	return nil
+}
+
+func (source *testSource) HasPermission(permission string) bool {
+	return source.permissions[permission]
+}
+
+func newTestDispatcher(result *string) Dispatcher {
+	dispatcher := NewDispatcher()
+	_ = dispatcher.Register(Literal("give").Then(
+		Argument("player", Word()).Suggests(func(ctx *Context, remaining string) []Suggestion {
+			return Suggestions("Notch", "jeb_")
+		}).Then(
+			Argument("amount", Integer(1, 64)).Executes(func(ctx *Context) error {
+				*result = ctx.GetString("player")
+				return nil
+			}),
+		),
+	))
+	_ = dispatcher.Register(Literal("stop").Permission("server.stop").Executes(func(ctx *Context) error {
+		*result = "stop"
+		return nil
+	}))
+	_ = dispatcher.Register(Literal("say").Then(
+		Argument("message", GreedyString()).Executes(func(ctx *Context) error {
+			*result = ctx.GetString("message")
+			return nil
+		}),
+	))
+	_ = dispatcher.Register(Literal("tp").Then(
+		Argument("x", Double(-math.MaxFloat64, math.MaxFloat64)).Executes(func(ctx *Context) error {
+			return nil
+		}),
+	))
+	return dispatcher
+}
+
+func TestDispatcher_Execute(t *testing.T) {
+	var result string
+	dispatcher := newTestDispatcher(&result)
+	source := &testSource{}
+
+	if err := dispatcher.Execute(source, "give Notch 32"); err != nil {
+		t.Fatal(err)
+	}
+	if result != "Notch" {
+		t.Errorf("Execute was incorrect, got: %s, want: %s.", result, "Notch")
+	}
+
+	if err := dispatcher.Execute(source, "say hello there"); err != nil {
+		t.Fatal(err)
+	}
+	if result != "hello there" {
+		t.Errorf("Execute was incorrect, got: %s, want: %s.", result, "hello there")
+	}
+
+	for input, want := range map[string]string{
+		"give Notch 65": "Integer must not be more than 64, found 65 at position 11: ...ive Notch <--[HERE]",
+		"give Notch":    "Unknown or incomplete command at position 10: give Notch<--[HERE]",
+		"stop":          "Unknown command at position 0: <--[HERE]",
+		"unknown":       "Unknown command at position 0: <--[HERE]",
+	} {
+		err := dispatcher.Execute(source, input)
+		if err == nil || err.Error() != want {
+			t.Errorf("Execute error was incorrect for %q, got: %v, want: %s.", input, err, want)
+		}
+	}
+
+	source.permissions = map[string]bool{"server.stop": true}
+	if err := dispatcher.Execute(source, "stop"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDispatcher_Suggest(t *testing.T) {
+	var result string
+	dispatcher := newTestDispatcher(&result)
+	source := &testSource{}
+
+	tests := []struct {
+		input string
+		start int
+		want  []string
+	}{
+		{"", 0, []string{"give", "say", "tp"}},
+		{"g", 0, []string{"give"}},
+		{"give ", 5, []string{"Notch", "jeb_"}},
+		{"give j", 5, []string{"jeb_"}},
+		{"st", 0, nil},
+	}
+
+	for _, test := range tests {
+		start, suggestions := dispatcher.Suggest(source, test.input)
+		var got []string
+		for _, suggestion := range suggestions {
+			got = append(got, suggestion.Text)
+		}
+
+		if start != test.start || len(got) != len(test.want) {
+			t.Errorf("Suggest was incorrect for %q, got: %d %v, want: %d %v.", test.input, start, got, test.start, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("Suggest was incorrect for %q, got: %v, want: %v.", test.input, got, test.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package command
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type (
+	// Source is whoever runs a command, like a player or the console
+	Source interface {
+		GetName() string
+		SendMessage(message []chat.Component) error
+		HasPermission(permission string) bool
+	}
+
+	Command            func(ctx *Context) error
+	Requirement        func(source Source) bool
+	SuggestionProvider func(ctx *Context, remaining string) []Suggestion
+
+	Suggestion struct {
+		Text    string
+		Tooltip []chat.Component
+	}
+
+	// Node is either a literal or an argument in the command tree.
+	// Nodes are meant to be built once and not modified after being registered.
+	Node struct {
+		name        string
+		argument    ArgumentType
+		command     Command
+		permission  string
+		requirement Requirement
+		suggestions SuggestionProvider
+		children    []*Node
+	}
+)
+
+func (node *Node) GetName() string {
+	return node.name
+}
+
+func (node *Node) IsLiteral() bool {
+	return node.argument == nil
+}
+
+func (node *Node) GetArgument() ArgumentType {
+	return node.argument
+}
+
+func (node *Node) GetCommand() Command {
+	return node.command
+}
+
+func (node *Node) GetPermission() string {
+	return node.permission
+}
+
+func (node *Node) HasSuggestions() bool {
+	return node.suggestions != nil
+}
+
+func (node *Node) GetChildren() []*Node {
+	return node.children
+}
+
+// CanUse checks the node permission and requirement against the source
+func (node *Node) CanUse(source Source) bool {
+	if node.permission != "" && !source.HasPermission(node.permission) {
+		return false
+	}
+	return node.requirement == nil || node.requirement(source)
+}
+
+// Then adds children to the node, literals are kept before arguments so they're matched first
+func (node *Node) Then(children ...*Node) *Node {
+	for _, child := range children {
+		index := len(node.children)
+		if child.IsLiteral() {
+			for i, other := range node.children {
+				if !other.IsLiteral() {
+					index = i
+					break
+				}
+			}
+		}
+
+		node.children = append(node.children, nil)
+		copy(node.children[index+1:], node.children[index:])
+		node.children[index] = child
+	}
+	return node
+}
+
+func (node *Node) Executes(command Command) *Node {
+	node.command = command
+	return node
+}
+
+func (node *Node) Permission(permission string) *Node {
+	node.permission = permission
+	return node
+}
+
+func (node *Node) Requires(requirement Requirement) *Node {
+	node.requirement = requirement
+	return node
+}
+
+func (node *Node) Suggests(provider SuggestionProvider) *Node {
+	node.suggestions = provider
+	return node
+}
+
+func (node *Node) parse(reader *StringReader, ctx *Context) error {
+	if node.IsLiteral() {
+		start := reader.GetCursor()
+		end := start
+		for end < len(reader.GetInput()) && reader.GetInput()[end] != ' ' {
+			end++
+		}
+
+		if reader.GetInput()[start:end] != node.name {
+			return reader.error("Incorrect argument for command")
+		}
+		reader.SetCursor(end)
+		return nil
+	}
+
+	value, err := node.argument.Parse(reader)
+	if err != nil {
+		return err
+	}
+	ctx.arguments[node.name] = value
+	return nil
+}
+
+func (node *Node) getSuggestions(ctx *Context, remaining string) []Suggestion {
+	if node.IsLiteral() {
+		return []Suggestion{{Text: node.name}}
+	}
+
+	if node.suggestions != nil {
+		return node.suggestions(ctx, remaining)
+	}
+
+	if _, ok := node.argument.(*boolArgument); ok {
+		return Suggestions("true", "false")
+	}
+	return nil
+}
+
+func Literal(name string) *Node {
+	return &Node{name: name}
+}
+
+func Argument(name string, argument ArgumentType) *Node {
+	return &Node{name: name, argument: argument}
+}
+
+// Suggestions creates suggestions without tooltips
+func Suggestions(texts ...string) []Suggestion {
+	var suggestions []Suggestion
+	for _, text := range texts {
+		suggestions = append(suggestions, Suggestion{Text: text})
+	}
+	return suggestions
+}
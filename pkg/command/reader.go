@@ -0,0 +1,203 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type (
+	StringReader struct {
+		input  string
+		cursor int
+	}
+
+	SyntaxError struct {
+		Message string
+		Input   string
+		Cursor  int
+	}
+)
+
+func (reader *StringReader) GetInput() string {
+	return reader.input
+}
+
+func (reader *StringReader) GetCursor() int {
+	return reader.cursor
+}
+
+func (reader *StringReader) SetCursor(cursor int) {
+	reader.cursor = cursor
+}
+
+func (reader *StringReader) GetRemaining() string {
+	return reader.input[reader.cursor:]
+}
+
+func (reader *StringReader) CanRead() bool {
+	return reader.cursor < len(reader.input)
+}
+
+func (reader *StringReader) Peek() byte {
+	return reader.input[reader.cursor]
+}
+
+func (reader *StringReader) Read() byte {
+	char := reader.input[reader.cursor]
+	reader.cursor++
+	return char
+}
+
+func (reader *StringReader) Skip() {
+	reader.cursor++
+}
+
+func (reader *StringReader) SkipWhitespace() {
+	for reader.CanRead() && reader.Peek() == ' ' {
+		reader.Skip()
+	}
+}
+
+func (reader *StringReader) ReadUnquotedString() string {
+	start := reader.cursor
+	for reader.CanRead() && isAllowedInUnquotedString(reader.Peek()) {
+		reader.Skip()
+	}
+	return reader.input[start:reader.cursor]
+}
+
+func (reader *StringReader) ReadQuotedString() (string, error) {
+	if !reader.CanRead() {
+		return "", nil
+	}
+
+	if reader.Peek() != '"' {
+		return "", reader.error("Expected quote to start a string")
+	}
+	reader.Skip()
+
+	var result []byte
+	escaped := false
+	for reader.CanRead() {
+		char := reader.Read()
+		if escaped {
+			if char != '"' && char != '\\' {
+				reader.cursor--
+				return "", reader.error("Invalid escape sequence '%c' in quoted string", char)
+			}
+			result = append(result, char)
+			escaped = false
+		} else if char == '\\' {
+			escaped = true
+		} else if char == '"' {
+			return string(result), nil
+		} else {
+			result = append(result, char)
+		}
+	}
+	return "", reader.error("Unclosed quoted string")
+}
+
+func (reader *StringReader) ReadString() (string, error) {
+	if reader.CanRead() && reader.Peek() == '"' {
+		return reader.ReadQuotedString()
+	}
+	return reader.ReadUnquotedString(), nil
+}
+
+func (reader *StringReader) ReadInt() (int32, error) {
+	start := reader.cursor
+	number := reader.readNumber()
+	if number == "" {
+		return 0, reader.error("Expected integer")
+	}
+
+	value, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		reader.cursor = start
+		return 0, reader.error("Invalid integer '%s'", number)
+	}
+	return int32(value), nil
+}
+
+func (reader *StringReader) ReadDouble() (float64, error) {
+	start := reader.cursor
+	number := reader.readNumber()
+	if number == "" {
+		return 0, reader.error("Expected double")
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		reader.cursor = start
+		return 0, reader.error("Invalid double '%s'", number)
+	}
+	return value, nil
+}
+
+func (reader *StringReader) ReadBool() (bool, error) {
+	start := reader.cursor
+	value := reader.ReadUnquotedString()
+	switch value {
+	case "":
+		return false, reader.error("Expected bool")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		reader.cursor = start
+		return false, reader.error("Invalid bool, expected true or false but found '%s'", value)
+	}
+}
+
+func (reader *StringReader) readNumber() string {
+	start := reader.cursor
+	for reader.CanRead() && isAllowedNumber(reader.Peek()) {
+		reader.Skip()
+	}
+	return reader.input[start:reader.cursor]
+}
+
+func (reader *StringReader) error(format string, args ...interface{}) error {
+	return &SyntaxError{
+		Message: fmt.Sprintf(format, args...),
+		Input:   reader.input,
+		Cursor:  reader.cursor,
+	}
+}
+
+func (reader *StringReader) clone() *StringReader {
+	return &StringReader{input: reader.input, cursor: reader.cursor}
+}
+
+// Error mimics the vanilla format, showing at most 10 characters before the error position
+func (err *SyntaxError) Error() string {
+	if err.Input == "" || err.Cursor < 0 {
+		return err.Message
+	}
+
+	cursor := err.Cursor
+	if cursor > len(err.Input) {
+		cursor = len(err.Input)
+	}
+
+	context := err.Input[:cursor]
+	if len(context) > 10 {
+		context = "..." + context[len(context)-10:]
+	}
+	return fmt.Sprintf("%s at position %d: %s<--[HERE]", err.Message, cursor, context)
+}
+
+func NewStringReader(input string) *StringReader {
+	return &StringReader{input: input}
+}
+
+func isAllowedNumber(char byte) bool {
+	return char >= '0' && char <= '9' || char == '.' || char == '-'
+}
+
+func isAllowedInUnquotedString(char byte) bool {
+	return char >= '0' && char <= '9' || char >= 'A' && char <= 'Z' || char >= 'a' && char <= 'z' ||
+		char == '_' || char == '-' || char == '.' || char == '+'
+}
@@ -0,0 +1,284 @@
+package permission
+
+import (
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Wildcard grants every permission, "lobby.*" grants every node below lobby
+const Wildcard = "*"
+
+type (
+	// Permissible is anything permissions can be checked against, like players and the console
+	Permissible interface {
+		HasPermission(permission string) bool
+		IsOperator() bool
+	}
+
+	Group struct {
+		Name        string          `json:"-"`
+		Default     bool            `json:"default,omitempty"`
+		Parents     []string        `json:"parents,omitempty"`
+		Permissions map[string]bool `json:"permissions,omitempty"`
+	}
+
+	User struct {
+		UniqueID    uuid.UUID       `json:"-"`
+		Name        string          `json:"name,omitempty"`
+		Operator    bool            `json:"operator,omitempty"`
+		Groups      []string        `json:"groups,omitempty"`
+		Permissions map[string]bool `json:"permissions,omitempty"`
+	}
+
+	Manager interface {
+		// HasPermission resolves the node for the user, operators have every node they aren't denied
+		HasPermission(uniqueID uuid.UUID, permission string) bool
+		IsOperator(uniqueID uuid.UUID) bool
+		GetUser(uniqueID uuid.UUID) (User, error)
+		SaveUser(user User) error
+		GetGroup(name string) (Group, bool)
+		GetGroups() []Group
+		SaveGroup(group Group) error
+		DeleteGroup(name string) error
+		SetStorage(storage Storage) error
+		Reload() error
+	}
+
+	manager struct {
+		mutex   sync.RWMutex
+		storage Storage
+		groups  map[string]Group
+		users   map[uuid.UUID]User
+	}
+)
+
+func (manager *manager) HasPermission(uniqueID uuid.UUID, permission string) bool {
+	user, err := manager.GetUser(uniqueID)
+	if err != nil {
+		return false
+	}
+
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+
+	if value, ok := Lookup(user.Permissions, permission); ok {
+		return value
+	}
+
+	groups := user.Groups
+	if len(groups) == 0 {
+		groups = manager.defaultGroups()
+	}
+
+	visited := make(map[string]bool)
+	for _, name := range groups {
+		if value, ok := manager.lookupGroup(name, permission, visited); ok {
+			return value
+		}
+	}
+	return user.Operator
+}
+
+func (manager *manager) IsOperator(uniqueID uuid.UUID) bool {
+	user, err := manager.GetUser(uniqueID)
+	return err == nil && user.Operator
+}
+
+func (manager *manager) GetUser(uniqueID uuid.UUID) (User, error) {
+	manager.mutex.RLock()
+	user, ok := manager.users[uniqueID]
+	storage := manager.storage
+	manager.mutex.RUnlock()
+	if ok {
+		return user, nil
+	}
+
+	loaded, err := storage.LoadUser(uniqueID)
+	if err != nil {
+		return User{UniqueID: uniqueID}, err
+	}
+	if loaded == nil {
+		loaded = &User{}
+	}
+	loaded.UniqueID = uniqueID
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if user, ok := manager.users[uniqueID]; ok {
+		return user, nil
+	}
+	manager.users[uniqueID] = *loaded
+	return *loaded, nil
+}
+
+func (manager *manager) SaveUser(user User) error {
+	user.Permissions = normalize(user.Permissions)
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if err := manager.storage.SaveUser(user); err != nil {
+		return err
+	}
+	manager.users[user.UniqueID] = user
+	return nil
+}
+
+func (manager *manager) GetGroup(name string) (Group, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	group, ok := manager.groups[strings.ToLower(name)]
+	return group, ok
+}
+
+func (manager *manager) GetGroups() []Group {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+
+	groups := make([]Group, 0, len(manager.groups))
+	for _, group := range manager.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+func (manager *manager) SaveGroup(group Group) error {
+	group.Name = strings.ToLower(group.Name)
+	group.Permissions = normalize(group.Permissions)
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if err := manager.storage.SaveGroup(group); err != nil {
+		return err
+	}
+	manager.groups[group.Name] = group
+	return nil
+}
+
+func (manager *manager) DeleteGroup(name string) error {
+	name = strings.ToLower(name)
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if err := manager.storage.DeleteGroup(name); err != nil {
+		return err
+	}
+	delete(manager.groups, name)
+	return nil
+}
+
+func (manager *manager) SetStorage(storage Storage) error {
+	manager.mutex.Lock()
+	manager.storage = storage
+	manager.mutex.Unlock()
+	return manager.Reload()
+}
+
+// Reload fetches every group again and forgets cached users so they are loaded on their next check
+func (manager *manager) Reload() error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	groups, err := manager.storage.LoadGroups()
+	if err != nil {
+		return err
+	}
+
+	manager.groups = make(map[string]Group, len(groups))
+	for _, group := range groups {
+		group.Name = strings.ToLower(group.Name)
+		manager.groups[group.Name] = group
+	}
+	manager.users = make(map[uuid.UUID]User)
+	return nil
+}
+
+// lookupGroup checks the group before its parents, depth first, must hold the mutex
+func (manager *manager) lookupGroup(name, permission string, visited map[string]bool) (bool, bool) {
+	name = strings.ToLower(name)
+	if visited[name] {
+		return false, false
+	}
+	visited[name] = true
+
+	group, ok := manager.groups[name]
+	if !ok {
+		return false, false
+	}
+
+	if value, ok := Lookup(group.Permissions, permission); ok {
+		return value, true
+	}
+
+	for _, parent := range group.Parents {
+		if value, ok := manager.lookupGroup(parent, permission, visited); ok {
+			return value, true
+		}
+	}
+	return false, false
+}
+
+// defaultGroups must hold the mutex
+func (manager *manager) defaultGroups() []string {
+	var groups []string
+	for _, group := range manager.groups {
+		if group.Default {
+			groups = append(groups, group.Name)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// Lookup finds the most specific node matching the permission, an exact node
+// wins over "a.b.*" which wins over "a.*" which wins over "*"
+func Lookup(nodes map[string]bool, permission string) (bool, bool) {
+	if len(nodes) == 0 {
+		return false, false
+	}
+
+	permission = strings.ToLower(permission)
+	if value, ok := nodes[permission]; ok {
+		return value, true
+	}
+
+	for node := permission; ; {
+		index := strings.LastIndex(node, ".")
+		if index < 0 {
+			break
+		}
+		node = node[:index]
+
+		if value, ok := nodes[node+"."+Wildcard]; ok {
+			return value, true
+		}
+	}
+
+	value, ok := nodes[Wildcard]
+	return value, ok
+}
+
+func normalize(nodes map[string]bool) map[string]bool {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]bool, len(nodes))
+	for node, value := range nodes {
+		normalized[strings.ToLower(node)] = value
+	}
+	return normalized
+}
+
+func NewManager(storage Storage) (Manager, error) {
+	manager := &manager{
+		storage: storage,
+		groups:  make(map[string]Group),
+		users:   make(map[uuid.UUID]User),
+	}
+	return manager, manager.Reload()
+}
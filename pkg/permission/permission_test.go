@@ -0,0 +1,97 @@
+package permission
+
+import (
+	"github.com/google/uuid"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	nodes := map[string]bool{
+		"*":            false,
+		"lobby.*":      true,
+		"lobby.fly":    false,
+		"lobby.chat.*": false,
+	}
+
+	tests := []struct {
+		permission    string
+		value, exists bool
+	}{
+		{"lobby.fly", false, true},
+		{"LOBBY.FLY", false, true},
+		{"lobby.build", true, true},
+		{"lobby.chat.color", false, true},
+		{"lobby", false, true},
+		{"other.node", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.permission, func(t *testing.T) {
+			value, exists := Lookup(nodes, test.permission)
+			if value != test.value || exists != test.exists {
+				t.Errorf("Lookup() = %v, %v, want %v, %v", value, exists, test.value, test.exists)
+			}
+		})
+	}
+
+	if _, exists := Lookup(map[string]bool{"lobby.fly": true}, "lobby"); exists {
+		t.Errorf("Lookup() found a node that isn't set")
+	}
+}
+
+func TestManager_HasPermission(t *testing.T) {
+	storage := NewFileStorage(filepath.Join(t.TempDir(), "permissions.json"))
+	manager, err := NewManager(storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, group := range []Group{
+		{Name: "default", Default: true, Permissions: map[string]bool{"lobby.chat": true}},
+		{Name: "vip", Parents: []string{"default", "staff"}, Permissions: map[string]bool{"lobby.fly": true}},
+		{Name: "staff", Parents: []string{"vip"}, Permissions: map[string]bool{"lobby.*": true, "lobby.chat": false}},
+	} {
+		if err := manager.SaveGroup(group); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	guest, vip, op := uuid.New(), uuid.New(), uuid.New()
+	for _, user := range []User{
+		{UniqueID: vip, Groups: []string{"vip"}, Permissions: map[string]bool{"lobby.kick": false}},
+		{UniqueID: op, Operator: true, Permissions: map[string]bool{"server.stop": false}},
+	} {
+		if err := manager.SaveUser(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reloading makes sure everything went through the storage
+	if err := manager.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		uniqueID   uuid.UUID
+		permission string
+		want       bool
+	}{
+		{"default group", guest, "lobby.chat", true},
+		{"no node", guest, "lobby.fly", false},
+		{"own group", vip, "lobby.fly", true},
+		{"first parent wins", vip, "lobby.chat", true},
+		{"inherited wildcard", vip, "lobby.build", true},
+		{"user node", vip, "lobby.kick", false},
+		{"operator", op, "lobby.fly", true},
+		{"operator default group", op, "lobby.chat", true},
+		{"operator denied", op, "server.stop", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := manager.HasPermission(test.uniqueID, test.permission); got != test.want {
+				t.Errorf("HasPermission() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
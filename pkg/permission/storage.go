@@ -0,0 +1,135 @@
+package permission
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"sync"
+)
+
+type (
+	// Storage persists groups and users, users are only loaded once they are needed
+	Storage interface {
+		LoadGroups() ([]Group, error)
+		SaveGroup(group Group) error
+		DeleteGroup(name string) error
+		// LoadUser returns nil when the user has nothing stored
+		LoadUser(uniqueID uuid.UUID) (*User, error)
+		SaveUser(user User) error
+	}
+
+	fileStorage struct {
+		mutex    sync.Mutex
+		fileName string
+	}
+
+	fileData struct {
+		Groups map[string]Group   `json:"groups"`
+		Users  map[uuid.UUID]User `json:"users"`
+	}
+)
+
+func (storage *fileStorage) LoadGroups() ([]Group, error) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	data, err := storage.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []Group
+	for name, group := range data.Groups {
+		group.Name = name
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (storage *fileStorage) SaveGroup(group Group) error {
+	return storage.update(func(data *fileData) {
+		data.Groups[group.Name] = group
+	})
+}
+
+func (storage *fileStorage) DeleteGroup(name string) error {
+	return storage.update(func(data *fileData) {
+		delete(data.Groups, name)
+	})
+}
+
+func (storage *fileStorage) LoadUser(uniqueID uuid.UUID) (*User, error) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	data, err := storage.read()
+	if err != nil {
+		return nil, err
+	}
+
+	if user, ok := data.Users[uniqueID]; ok {
+		user.UniqueID = uniqueID
+		return &user, nil
+	}
+	return nil, nil
+}
+
+func (storage *fileStorage) SaveUser(user User) error {
+	return storage.update(func(data *fileData) {
+		if !user.Operator && len(user.Groups) == 0 && len(user.Permissions) == 0 {
+			delete(data.Users, user.UniqueID)
+		} else {
+			data.Users[user.UniqueID] = user
+		}
+	})
+}
+
+func (storage *fileStorage) update(fn func(data *fileData)) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	data, err := storage.read()
+	if err != nil {
+		return err
+	}
+	fn(data)
+
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(storage.fileName, bytes, 0644)
+}
+
+// read must hold the mutex
+func (storage *fileStorage) read() (*fileData, error) {
+	data := &fileData{
+		Groups: make(map[string]Group),
+		Users:  make(map[uuid.UUID]User),
+	}
+
+	bytes, err := os.ReadFile(storage.fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return data, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bytes, data); err != nil {
+		return nil, err
+	}
+	if data.Groups == nil {
+		data.Groups = make(map[string]Group)
+	}
+	if data.Users == nil {
+		data.Users = make(map[uuid.UUID]User)
+	}
+	return data, nil
+}
+
+// NewFileStorage keeps everything in a single json file, which is created on the first change
+func NewFileStorage(fileName string) Storage {
+	return &fileStorage{fileName: fileName}
+}
@@ -0,0 +1,27 @@
+package blocks
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"testing"
+)
+
+func TestGetBlockID(t *testing.T) {
+	tests := []struct {
+		block string
+		proto protocol.Protocol
+		id    int
+	}{
+		{"minecraft:stone", protocol.V1_15, 1},
+		{"minecraft:stone", protocol.V1_20_2, 1},
+		{"minecraft:water[level=0]", protocol.V1_18_2, 34},
+		{"minecraft:water[level=0]", protocol.V1_19_4, 80},
+		{"minecraft:deepslate[axis=y]", protocol.V1_16_4, 0},
+		{"minecraft:deepslate[axis=y]", protocol.V1_17_1, 18684},
+		{"minecraft:cherry_log[axis=y]", protocol.V1_20_2, 146},
+	}
+	for _, test := range tests {
+		if id := GetBlockID(test.block, test.proto); id != test.id {
+			t.Errorf("GetBlockID(%q, %d) = %d, want %d", test.block, test.proto, id, test.id)
+		}
+	}
+}
@@ -3,12 +3,16 @@ package protocol
 import (
 	"errors"
 	"github.com/r4g3baby/mcserver/pkg/util/nbt"
+	"strings"
 )
 
 type (
+	// DimensionCodec holds the registries sent when joining, which grew beyond dimensions and biomes on 1.19+
 	DimensionCodec struct {
-		Dimensions []Dimension
-		Biomes     []Biome
+		Dimensions  []Dimension
+		Biomes      []Biome
+		ChatTypes   []ChatType
+		DamageTypes []DamageType
 	}
 
 	Dimension struct {
@@ -29,6 +33,12 @@ type (
 		RespawnAnchorWorks bool
 		FixedTime          *int64
 		Shrunk             bool
+		// MinY and Height are only sent from 1.17, older clients always have a 256 blocks tall world
+		MinY   int32
+		Height int32
+		// Monster spawn light levels are only sent from 1.19
+		MonsterSpawnLightLevel      int32
+		MonsterSpawnBlockLightLimit int32
 	}
 
 	Biome struct {
@@ -61,81 +71,97 @@ type (
 
 var (
 	Overworld = Dimension{
-		ID:                 0,
-		Name:               "minecraft:overworld",
-		BedWorks:           true,
-		HasCeiling:         false,
-		CoordinateScale:    1,
-		PiglinSafe:         false,
-		HasSkylight:        true,
-		Ultrawarm:          false,
-		Infiniburn:         "minecraft:infiniburn_overworld",
-		Effects:            "minecraft:overworld",
-		HasRaids:           true,
-		AmbientLight:       0,
-		LogicalHeight:      256,
-		Natural:            true,
-		RespawnAnchorWorks: false,
-		Shrunk:             true,
+		ID:                          0,
+		Name:                        "minecraft:overworld",
+		BedWorks:                    true,
+		HasCeiling:                  false,
+		CoordinateScale:             1,
+		PiglinSafe:                  false,
+		HasSkylight:                 true,
+		Ultrawarm:                   false,
+		Infiniburn:                  "minecraft:infiniburn_overworld",
+		Effects:                     "minecraft:overworld",
+		HasRaids:                    true,
+		AmbientLight:                0,
+		LogicalHeight:               384,
+		Natural:                     true,
+		RespawnAnchorWorks:          false,
+		Shrunk:                      true,
+		MinY:                        -64,
+		Height:                      384,
+		MonsterSpawnLightLevel:      7,
+		MonsterSpawnBlockLightLimit: 0,
 	}
 
 	OverworldCaves = Dimension{
-		ID:                 1,
-		Name:               "minecraft:overworld_caves",
-		BedWorks:           true,
-		HasCeiling:         true,
-		CoordinateScale:    1,
-		PiglinSafe:         false,
-		HasSkylight:        true,
-		Ultrawarm:          false,
-		Infiniburn:         "minecraft:infiniburn_overworld",
-		Effects:            "minecraft:overworld",
-		HasRaids:           true,
-		AmbientLight:       0,
-		LogicalHeight:      256,
-		Natural:            true,
-		RespawnAnchorWorks: false,
-		Shrunk:             false,
+		ID:                          1,
+		Name:                        "minecraft:overworld_caves",
+		BedWorks:                    true,
+		HasCeiling:                  true,
+		CoordinateScale:             1,
+		PiglinSafe:                  false,
+		HasSkylight:                 true,
+		Ultrawarm:                   false,
+		Infiniburn:                  "minecraft:infiniburn_overworld",
+		Effects:                     "minecraft:overworld",
+		HasRaids:                    true,
+		AmbientLight:                0,
+		LogicalHeight:               384,
+		Natural:                     true,
+		RespawnAnchorWorks:          false,
+		Shrunk:                      false,
+		MinY:                        -64,
+		Height:                      384,
+		MonsterSpawnLightLevel:      7,
+		MonsterSpawnBlockLightLimit: 0,
 	}
 
 	TheNether = Dimension{
-		ID:                 2,
-		Name:               "minecraft:the_nether",
-		BedWorks:           false,
-		HasCeiling:         true,
-		CoordinateScale:    8,
-		PiglinSafe:         true,
-		HasSkylight:        false,
-		Ultrawarm:          true,
-		Infiniburn:         "minecraft:infiniburn_nether",
-		Effects:            "minecraft:the_nether",
-		HasRaids:           false,
-		AmbientLight:       0.1,
-		LogicalHeight:      128,
-		Natural:            false,
-		RespawnAnchorWorks: true,
-		FixedTime:          func(i int64) *int64 { return &i }(18000),
-		Shrunk:             true,
+		ID:                          2,
+		Name:                        "minecraft:the_nether",
+		BedWorks:                    false,
+		HasCeiling:                  true,
+		CoordinateScale:             8,
+		PiglinSafe:                  true,
+		HasSkylight:                 false,
+		Ultrawarm:                   true,
+		Infiniburn:                  "minecraft:infiniburn_nether",
+		Effects:                     "minecraft:the_nether",
+		HasRaids:                    false,
+		AmbientLight:                0.1,
+		LogicalHeight:               128,
+		Natural:                     false,
+		RespawnAnchorWorks:          true,
+		FixedTime:                   func(i int64) *int64 { return &i }(18000),
+		Shrunk:                      true,
+		MinY:                        0,
+		Height:                      256,
+		MonsterSpawnLightLevel:      7,
+		MonsterSpawnBlockLightLimit: 15,
 	}
 
 	TheEnd = Dimension{
-		ID:                 3,
-		Name:               "minecraft:the_end",
-		BedWorks:           false,
-		HasCeiling:         false,
-		CoordinateScale:    1,
-		PiglinSafe:         false,
-		HasSkylight:        false,
-		Ultrawarm:          false,
-		Infiniburn:         "minecraft:infiniburn_end",
-		Effects:            "minecraft:the_end",
-		HasRaids:           true,
-		AmbientLight:       0,
-		LogicalHeight:      256,
-		Natural:            false,
-		RespawnAnchorWorks: false,
-		FixedTime:          func(i int64) *int64 { return &i }(6000),
-		Shrunk:             false,
+		ID:                          3,
+		Name:                        "minecraft:the_end",
+		BedWorks:                    false,
+		HasCeiling:                  false,
+		CoordinateScale:             1,
+		PiglinSafe:                  false,
+		HasSkylight:                 false,
+		Ultrawarm:                   false,
+		Infiniburn:                  "minecraft:infiniburn_end",
+		Effects:                     "minecraft:the_end",
+		HasRaids:                    true,
+		AmbientLight:                0,
+		LogicalHeight:               256,
+		Natural:                     false,
+		RespawnAnchorWorks:          false,
+		FixedTime:                   func(i int64) *int64 { return &i }(6000),
+		Shrunk:                      false,
+		MinY:                        0,
+		Height:                      256,
+		MonsterSpawnLightLevel:      7,
+		MonsterSpawnBlockLightLimit: 0,
 	}
 
 	DefaultDimensionCodec = DimensionCodec{
@@ -204,6 +230,8 @@ var (
 				},
 			},
 		}},
+		ChatTypes:   DefaultChatTypes,
+		DamageTypes: DefaultDamageTypes,
 	}
 )
 
@@ -223,11 +251,11 @@ func (codec DimensionCodec) ToCompound(proto Protocol) nbt.CompoundTag {
 			biomes = append(biomes, nbt.CompoundTag{
 				"id":      nbt.IntTag(biome.ID),
 				"name":    nbt.StringTag(biome.Name),
-				"element": biome.ToCompound(),
+				"element": biome.ToCompound(proto),
 			})
 		}
 
-		return nbt.CompoundTag{
+		compound := nbt.CompoundTag{
 			"minecraft:dimension_type": nbt.CompoundTag{
 				"type":  nbt.StringTag("minecraft:dimension_type"),
 				"value": dimensions,
@@ -237,6 +265,55 @@ func (codec DimensionCodec) ToCompound(proto Protocol) nbt.CompoundTag {
 				"value": biomes,
 			},
 		}
+
+		if proto >= V1_19 {
+			var chatTypes nbt.ListTag
+			for _, chatType := range codec.ChatTypes {
+				if element, ok := chatType.ToCompound(proto); ok {
+					chatTypes = append(chatTypes, nbt.CompoundTag{
+						"id":      nbt.IntTag(chatType.ID),
+						"name":    nbt.StringTag(chatType.Name),
+						"element": element,
+					})
+				}
+			}
+
+			compound["minecraft:chat_type"] = nbt.CompoundTag{
+				"type":  nbt.StringTag("minecraft:chat_type"),
+				"value": chatTypes,
+			}
+		}
+
+		if proto >= V1_19_4 {
+			// Damage types are never referenced by id, so they are numbered in the order they're sent
+			var damageTypes nbt.ListTag
+			for _, damageType := range codec.DamageTypes {
+				if proto >= damageType.Since {
+					damageTypes = append(damageTypes, nbt.CompoundTag{
+						"id":      nbt.IntTag(len(damageTypes)),
+						"name":    nbt.StringTag(damageType.Name),
+						"element": damageType.ToCompound(),
+					})
+				}
+			}
+
+			compound["minecraft:damage_type"] = nbt.CompoundTag{
+				"type":  nbt.StringTag("minecraft:damage_type"),
+				"value": damageTypes,
+			}
+		}
+
+		if proto >= V1_20 {
+			// Armor trims are required from 1.20, but we don't have any
+			for _, registry := range []string{"minecraft:trim_pattern", "minecraft:trim_material"} {
+				compound[registry] = nbt.CompoundTag{
+					"type":  nbt.StringTag(registry),
+					"value": nbt.ListTag{},
+				}
+			}
+		}
+
+		return compound
 	}
 
 	for _, dim := range codec.Dimensions {
@@ -248,6 +325,17 @@ func (codec DimensionCodec) ToCompound(proto Protocol) nbt.CompoundTag {
 }
 
 func (dim Dimension) ToCompound(proto Protocol) nbt.CompoundTag {
+	// Infiniburn became a block tag reference on 1.18.2
+	infiniburn := strings.TrimPrefix(dim.Infiniburn, "#")
+	if proto >= V1_18_2 {
+		infiniburn = "#" + infiniburn
+	}
+
+	logicalHeight := dim.LogicalHeight
+	if proto < V1_17 && logicalHeight > 256 {
+		logicalHeight = 256
+	}
+
 	compound := nbt.CompoundTag{
 		"bed_works":            nbt.ByteTag(b2i(dim.BedWorks)),
 		"has_ceiling":          nbt.ByteTag(b2i(dim.HasCeiling)),
@@ -255,11 +343,11 @@ func (dim Dimension) ToCompound(proto Protocol) nbt.CompoundTag {
 		"piglin_safe":          nbt.ByteTag(b2i(dim.PiglinSafe)),
 		"has_skylight":         nbt.ByteTag(b2i(dim.HasSkylight)),
 		"ultrawarm":            nbt.ByteTag(b2i(dim.Ultrawarm)),
-		"infiniburn":           nbt.StringTag(dim.Infiniburn),
+		"infiniburn":           nbt.StringTag(infiniburn),
 		"effects":              nbt.StringTag(dim.Effects),
 		"has_raids":            nbt.ByteTag(b2i(dim.HasRaids)),
 		"ambient_light":        nbt.FloatTag(dim.AmbientLight),
-		"logical_height":       nbt.IntTag(dim.LogicalHeight),
+		"logical_height":       nbt.IntTag(logicalHeight),
 		"natural":              nbt.ByteTag(b2i(dim.Natural)),
 		"respawn_anchor_works": nbt.ByteTag(b2i(dim.RespawnAnchorWorks)),
 	}
@@ -273,17 +361,23 @@ func (dim Dimension) ToCompound(proto Protocol) nbt.CompoundTag {
 		compound["shrunk"] = nbt.ByteTag(b2i(dim.Shrunk))
 	}
 
+	if proto >= V1_17 {
+		compound["min_y"] = nbt.IntTag(dim.MinY)
+		compound["height"] = nbt.IntTag(dim.Height)
+	}
+
+	if proto >= V1_19 {
+		compound["monster_spawn_light_level"] = nbt.IntTag(dim.MonsterSpawnLightLevel)
+		compound["monster_spawn_block_light_limit"] = nbt.IntTag(dim.MonsterSpawnBlockLightLimit)
+	}
+
 	return compound
 }
 
-func (biome Biome) ToCompound() nbt.CompoundTag {
-	return nbt.CompoundTag{
-		"category":      nbt.StringTag(biome.Category),
-		"temperature":   nbt.FloatTag(biome.Temperature),
-		"downfall":      nbt.FloatTag(biome.Downfall),
-		"depth":         nbt.FloatTag(biome.Depth),
-		"precipitation": nbt.StringTag(biome.Precipitation),
-		"scale":         nbt.FloatTag(biome.Scale),
+func (biome Biome) ToCompound(proto Protocol) nbt.CompoundTag {
+	compound := nbt.CompoundTag{
+		"temperature": nbt.FloatTag(biome.Temperature),
+		"downfall":    nbt.FloatTag(biome.Downfall),
 		"effects": nbt.CompoundTag{
 			"water_fog_color": nbt.IntTag(biome.Effects.WaterFogColor),
 			"water_color":     nbt.IntTag(biome.Effects.WaterColor),
@@ -297,6 +391,23 @@ func (biome Biome) ToCompound() nbt.CompoundTag {
 			},
 		},
 	}
+
+	if proto < V1_18 {
+		compound["depth"] = nbt.FloatTag(biome.Depth)
+		compound["scale"] = nbt.FloatTag(biome.Scale)
+	}
+
+	if proto < V1_19 {
+		compound["category"] = nbt.StringTag(biome.Category)
+	}
+
+	if proto >= V1_19_4 {
+		compound["has_precipitation"] = nbt.ByteTag(b2i(biome.Precipitation != "none"))
+	} else {
+		compound["precipitation"] = nbt.StringTag(biome.Precipitation)
+	}
+
+	return compound
 }
 
 func DimensionCodecFromTag(tag nbt.Tag, proto Protocol) (DimensionCodec, error) {
@@ -469,7 +580,7 @@ func DimensionFromTag(tag nbt.Tag) (Dimension, error) {
 	var infiniburn string
 	if value, ok := dim["infiniburn"]; ok {
 		if tag, ok := value.(nbt.StringTag); ok {
-			infiniburn = string(tag)
+			infiniburn = strings.TrimPrefix(string(tag), "#")
 		}
 	}
 
@@ -530,24 +641,57 @@ func DimensionFromTag(tag nbt.Tag) (Dimension, error) {
 		}
 	}
 
+	var minY int32
+	if value, ok := dim["min_y"]; ok {
+		if tag, ok := value.(nbt.IntTag); ok {
+			minY = int32(tag)
+		}
+	}
+
+	// Dimensions from before 1.17 were always 256 blocks tall
+	var height int32 = 256
+	if value, ok := dim["height"]; ok {
+		if tag, ok := value.(nbt.IntTag); ok {
+			height = int32(tag)
+		}
+	}
+
+	var monsterSpawnLightLevel int32
+	if value, ok := dim["monster_spawn_light_level"]; ok {
+		if tag, ok := value.(nbt.IntTag); ok {
+			monsterSpawnLightLevel = int32(tag)
+		}
+	}
+
+	var monsterSpawnBlockLightLimit int32
+	if value, ok := dim["monster_spawn_block_light_limit"]; ok {
+		if tag, ok := value.(nbt.IntTag); ok {
+			monsterSpawnBlockLightLimit = int32(tag)
+		}
+	}
+
 	return Dimension{
-		ID:                 id,
-		Name:               name,
-		BedWorks:           bedWorks,
-		HasCeiling:         hasCeiling,
-		CoordinateScale:    coordinateScale,
-		PiglinSafe:         piglinSafe,
-		HasSkylight:        hasSkylight,
-		Ultrawarm:          ultrawarm,
-		Infiniburn:         infiniburn,
-		Effects:            effects,
-		HasRaids:           hasRaids,
-		AmbientLight:       ambientLight,
-		LogicalHeight:      logicalHeight,
-		Natural:            natural,
-		RespawnAnchorWorks: respawnAnchorWorks,
-		FixedTime:          fixedTime,
-		Shrunk:             shrunk,
+		ID:                          id,
+		Name:                        name,
+		BedWorks:                    bedWorks,
+		HasCeiling:                  hasCeiling,
+		CoordinateScale:             coordinateScale,
+		PiglinSafe:                  piglinSafe,
+		HasSkylight:                 hasSkylight,
+		Ultrawarm:                   ultrawarm,
+		Infiniburn:                  infiniburn,
+		Effects:                     effects,
+		HasRaids:                    hasRaids,
+		AmbientLight:                ambientLight,
+		LogicalHeight:               logicalHeight,
+		Natural:                     natural,
+		RespawnAnchorWorks:          respawnAnchorWorks,
+		FixedTime:                   fixedTime,
+		Shrunk:                      shrunk,
+		MinY:                        minY,
+		Height:                      height,
+		MonsterSpawnLightLevel:      monsterSpawnLightLevel,
+		MonsterSpawnBlockLightLimit: monsterSpawnBlockLightLimit,
 	}, nil
 }
 
@@ -606,6 +750,16 @@ func BiomeFromTag(tag nbt.Tag) (Biome, error) {
 		}
 	}
 
+	// Only the lack of precipitation is known from 1.19.4
+	if value, ok := biome["has_precipitation"]; ok {
+		if tag, ok := value.(nbt.ByteTag); ok {
+			precipitation = "none"
+			if i2b(int8(tag)) {
+				precipitation = "rain"
+			}
+		}
+	}
+
 	var scale float32
 	if value, ok := biome["scale"]; ok {
 		if tag, ok := value.(nbt.FloatTag); ok {
@@ -0,0 +1,73 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	GameMode   uint8
+	Difficulty uint8
+)
+
+const (
+	Survival GameMode = iota
+	Creative
+	Adventure
+	Spectator
+)
+
+func (gameMode GameMode) String() string {
+	switch gameMode {
+	case Survival:
+		return "Survival"
+	case Creative:
+		return "Creative"
+	case Adventure:
+		return "Adventure"
+	case Spectator:
+		return "Spectator"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	Peaceful Difficulty = iota
+	Easy
+	Normal
+	Hard
+)
+
+func (difficulty Difficulty) String() string {
+	switch difficulty {
+	case Peaceful:
+		return "Peaceful"
+	case Easy:
+		return "Easy"
+	case Normal:
+		return "Normal"
+	case Hard:
+		return "Hard"
+	default:
+		return "Unknown"
+	}
+}
+
+func ParseGameMode(name string) (GameMode, error) {
+	for gameMode := Survival; gameMode <= Spectator; gameMode++ {
+		if strings.EqualFold(name, gameMode.String()) {
+			return gameMode, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown game mode %q", name)
+}
+
+func ParseDifficulty(name string) (Difficulty, error) {
+	for difficulty := Peaceful; difficulty <= Hard; difficulty++ {
+		if strings.EqualFold(name, difficulty.String()) {
+			return difficulty, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown difficulty %q", name)
+}
@@ -0,0 +1,21 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketConfigurationInFinish acknowledges the finish, the client only expects play packets after sending it
+type PacketConfigurationInFinish struct{}
+
+func (packet *PacketConfigurationInFinish) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ServerBound, packet)
+}
+
+func (packet *PacketConfigurationInFinish) Read(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
+
+func (packet *PacketConfigurationInFinish) Write(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
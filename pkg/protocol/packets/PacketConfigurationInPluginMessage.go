@@ -0,0 +1,48 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketConfigurationInPluginMessage data takes the rest of the packet, it has no length of its own
+type PacketConfigurationInPluginMessage struct {
+	Channel string
+	Data    []byte
+}
+
+func (packet *PacketConfigurationInPluginMessage) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ServerBound, packet)
+}
+
+func (packet *PacketConfigurationInPluginMessage) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	channel, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.Channel = channel
+
+	if buffer.Len() > 32767 {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+	packet.Data = append([]byte(nil), buffer.Next(buffer.Len())...)
+
+	return nil
+}
+
+func (packet *PacketConfigurationInPluginMessage) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.Channel, 32767); err != nil {
+		return err
+	}
+
+	if len(packet.Data) > 32767 {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type (
+	PacketConfigurationInResourcePack struct {
+		Result ResourcePackResult
+	}
+
+	ResourcePackResult int32
+)
+
+const (
+	LoadedResourcePackResult ResourcePackResult = iota
+	DeclinedResourcePackResult
+	FailedResourcePackResult
+	AcceptedResourcePackResult
+)
+
+func (packet *PacketConfigurationInResourcePack) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ServerBound, packet)
+}
+
+func (packet *PacketConfigurationInResourcePack) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	result, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.Result = ResourcePackResult(result)
+
+	return nil
+}
+
+func (packet *PacketConfigurationInResourcePack) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteVarInt(int32(packet.Result)); err != nil {
+		return err
+	}
+
+	return nil
+}
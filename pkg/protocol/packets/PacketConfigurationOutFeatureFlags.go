@@ -0,0 +1,48 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketConfigurationOutFeatureFlags enables experimental features, clients need at least minecraft:vanilla
+type PacketConfigurationOutFeatureFlags struct {
+	Flags []string
+}
+
+func (packet *PacketConfigurationOutFeatureFlags) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ClientBound, packet)
+}
+
+func (packet *PacketConfigurationOutFeatureFlags) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	count, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+
+	var flags []string
+	for i := count; i > 0; i-- {
+		flag, err := buffer.ReadUtf(32767)
+		if err != nil {
+			return err
+		}
+		flags = append(flags, flag)
+	}
+	packet.Flags = flags
+
+	return nil
+}
+
+func (packet *PacketConfigurationOutFeatureFlags) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteVarInt(int32(len(packet.Flags))); err != nil {
+		return err
+	}
+
+	for _, flag := range packet.Flags {
+		if err := buffer.WriteUtf(flag, 32767); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketConfigurationOutFinish tells the client it got everything it needs to start playing
+type PacketConfigurationOutFinish struct{}
+
+func (packet *PacketConfigurationOutFinish) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ClientBound, packet)
+}
+
+func (packet *PacketConfigurationOutFinish) Read(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
+
+func (packet *PacketConfigurationOutFinish) Write(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
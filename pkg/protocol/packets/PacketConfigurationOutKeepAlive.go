@@ -0,0 +1,32 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketConfigurationOutKeepAlive struct {
+	KeepAliveID int32
+}
+
+func (packet *PacketConfigurationOutKeepAlive) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ClientBound, packet)
+}
+
+func (packet *PacketConfigurationOutKeepAlive) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	keepAliveID, err := buffer.ReadInt64()
+	if err != nil {
+		return err
+	}
+	packet.KeepAliveID = int32(keepAliveID)
+
+	return nil
+}
+
+func (packet *PacketConfigurationOutKeepAlive) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteInt64(int64(packet.KeepAliveID)); err != nil {
+		return err
+	}
+
+	return nil
+}
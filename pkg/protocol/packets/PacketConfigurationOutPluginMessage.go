@@ -0,0 +1,51 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketConfigurationOutPluginMessage data takes the rest of the packet, it has no length of its own
+type PacketConfigurationOutPluginMessage struct {
+	Channel string
+	Data    []byte
+}
+
+// maxPluginMessageLength is how much data servers can send in a single plugin message
+const maxPluginMessageLength = 1048576
+
+func (packet *PacketConfigurationOutPluginMessage) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ClientBound, packet)
+}
+
+func (packet *PacketConfigurationOutPluginMessage) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	channel, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.Channel = channel
+
+	if buffer.Len() > maxPluginMessageLength {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+	packet.Data = append([]byte(nil), buffer.Next(buffer.Len())...)
+
+	return nil
+}
+
+func (packet *PacketConfigurationOutPluginMessage) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.Channel, 32767); err != nil {
+		return err
+	}
+
+	if len(packet.Data) > maxPluginMessageLength {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
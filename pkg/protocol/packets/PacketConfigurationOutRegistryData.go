@@ -0,0 +1,39 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/nbt"
+)
+
+// PacketConfigurationOutRegistryData holds the codec that was part of the join game packet before 1.20.2
+type PacketConfigurationOutRegistryData struct {
+	DimensionCodec protocol.DimensionCodec
+}
+
+func (packet *PacketConfigurationOutRegistryData) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ClientBound, packet)
+}
+
+func (packet *PacketConfigurationOutRegistryData) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	dimensionCodecTag, err := nbt.ReadUnnamed(buffer)
+	if err != nil {
+		return err
+	}
+
+	dimensionCodec, err := protocol.DimensionCodecFromTag(dimensionCodecTag, proto)
+	if err != nil {
+		return err
+	}
+	packet.DimensionCodec = dimensionCodec
+
+	return nil
+}
+
+func (packet *PacketConfigurationOutRegistryData) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := nbt.WriteUnnamed(buffer, packet.DimensionCodec.ToCompound(proto)); err != nil {
+		return err
+	}
+
+	return nil
+}
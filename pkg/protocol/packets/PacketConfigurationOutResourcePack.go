@@ -0,0 +1,90 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+// PacketConfigurationOutResourcePack asks the client to download a resource pack, Hash is its hex encoded sha1
+type PacketConfigurationOutResourcePack struct {
+	URL    string
+	Hash   string
+	Forced bool
+	Prompt []chat.Component
+}
+
+func (packet *PacketConfigurationOutResourcePack) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Configuration, protocol.ClientBound, packet)
+}
+
+func (packet *PacketConfigurationOutResourcePack) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	url, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.URL = url
+
+	hash, err := buffer.ReadUtf(40)
+	if err != nil {
+		return err
+	}
+	packet.Hash = hash
+
+	forced, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.Forced = forced
+
+	hasPrompt, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+
+	if hasPrompt {
+		promptStr, err := buffer.ReadUtf(262144)
+		if err != nil {
+			return err
+		}
+
+		prompt, err := chat.FromJSON([]byte(promptStr))
+		if err != nil {
+			return err
+		}
+		packet.Prompt = prompt
+	}
+
+	return nil
+}
+
+func (packet *PacketConfigurationOutResourcePack) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.URL, 32767); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(packet.Hash, 40); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.Forced); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.Prompt != nil); err != nil {
+		return err
+	}
+
+	if packet.Prompt != nil {
+		prompt, err := chat.ToJSON(packet.Prompt)
+		if err != nil {
+			return err
+		}
+
+		if err := buffer.WriteUtf(string(prompt), 262144); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketLoginInAcknowledged is sent by 1.20.2+ clients once they got the login success, moving them into configuration
+type PacketLoginInAcknowledged struct{}
+
+func (packet *PacketLoginInAcknowledged) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Login, protocol.ServerBound, packet)
+}
+
+func (packet *PacketLoginInAcknowledged) Read(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
+
+func (packet *PacketLoginInAcknowledged) Write(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
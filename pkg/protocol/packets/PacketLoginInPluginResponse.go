@@ -0,0 +1,60 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketLoginInPluginResponse answers a PacketLoginOutPluginRequest, clients that
+// don't understand the channel answer it without being successful and without data
+type PacketLoginInPluginResponse struct {
+	MessageID  int32
+	Successful bool
+	Data       []byte
+}
+
+func (packet *PacketLoginInPluginResponse) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Login, protocol.ServerBound, packet)
+}
+
+func (packet *PacketLoginInPluginResponse) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	messageID, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.MessageID = messageID
+
+	successful, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.Successful = successful
+
+	if buffer.Len() > maxPluginMessageLength {
+		return errors.New("plugin response data is longer than maximum allowed")
+	}
+	packet.Data = append([]byte(nil), buffer.Next(buffer.Len())...)
+
+	return nil
+}
+
+func (packet *PacketLoginInPluginResponse) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteVarInt(packet.MessageID); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.Successful); err != nil {
+		return err
+	}
+
+	if len(packet.Data) > maxPluginMessageLength {
+		return errors.New("plugin response data is longer than maximum allowed")
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
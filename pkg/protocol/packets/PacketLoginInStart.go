@@ -1,32 +1,133 @@
 package packets
 
 import (
+	"github.com/google/uuid"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
 )
 
-type PacketLoginInStart struct {
-	Username string
-}
+type (
+	PacketLoginInStart struct {
+		Username  string
+		PublicKey *PlayerPublicKey
+		UniqueID  uuid.UUID
+	}
+
+	// PlayerPublicKey is the chat signing key clients sent while logging in from 1.19 to 1.19.2
+	PlayerPublicKey struct {
+		ExpiresAt int64
+		Key       []byte
+		Signature []byte
+	}
+)
 
 func (packet *PacketLoginInStart) GetID(proto protocol.Protocol) (int32, error) {
 	return GetID(proto, protocol.Login, protocol.ServerBound, packet)
 }
 
-func (packet *PacketLoginInStart) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+func (packet *PacketLoginInStart) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
 	username, err := buffer.ReadUtf(16)
 	if err != nil {
 		return err
 	}
 	packet.Username = username
 
+	if proto >= protocol.V1_19 && proto < protocol.V1_19_3 {
+		hasPublicKey, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if hasPublicKey {
+			expiresAt, err := buffer.ReadInt64()
+			if err != nil {
+				return err
+			}
+
+			key, err := buffer.ReadByteArray(512)
+			if err != nil {
+				return err
+			}
+
+			signature, err := buffer.ReadByteArray(4096)
+			if err != nil {
+				return err
+			}
+
+			packet.PublicKey = &PlayerPublicKey{
+				ExpiresAt: expiresAt,
+				Key:       key,
+				Signature: signature,
+			}
+		}
+	}
+
+	if proto >= protocol.V1_20_2 {
+		uniqueID, err := buffer.ReadUUID()
+		if err != nil {
+			return err
+		}
+		packet.UniqueID = uniqueID
+	} else if proto >= protocol.V1_19_1 {
+		hasUniqueID, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if hasUniqueID {
+			uniqueID, err := buffer.ReadUUID()
+			if err != nil {
+				return err
+			}
+			packet.UniqueID = uniqueID
+		}
+	}
+
 	return nil
 }
 
-func (packet *PacketLoginInStart) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+func (packet *PacketLoginInStart) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
 	if err := buffer.WriteUtf(packet.Username, 16); err != nil {
 		return err
 	}
 
+	if proto >= protocol.V1_19 && proto < protocol.V1_19_3 {
+		if err := buffer.WriteBool(packet.PublicKey != nil); err != nil {
+			return err
+		}
+
+		if packet.PublicKey != nil {
+			if err := buffer.WriteInt64(packet.PublicKey.ExpiresAt); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteByteArray(packet.PublicKey.Key, 512); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteByteArray(packet.PublicKey.Signature, 4096); err != nil {
+				return err
+			}
+		}
+	}
+
+	if proto >= protocol.V1_20_2 {
+		if err := buffer.WriteUUID(packet.UniqueID); err != nil {
+			return err
+		}
+	} else if proto >= protocol.V1_19_1 {
+		// A nil id means the client didn't send one
+		hasUniqueID := packet.UniqueID != uuid.Nil
+		if err := buffer.WriteBool(hasUniqueID); err != nil {
+			return err
+		}
+
+		if hasUniqueID {
+			if err := buffer.WriteUUID(packet.UniqueID); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,59 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketLoginOutPluginRequest asks the client a login query, only sent from 1.13
+type PacketLoginOutPluginRequest struct {
+	MessageID int32
+	Channel   string
+	Data      []byte
+}
+
+func (packet *PacketLoginOutPluginRequest) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Login, protocol.ClientBound, packet)
+}
+
+func (packet *PacketLoginOutPluginRequest) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	messageID, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.MessageID = messageID
+
+	channel, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.Channel = channel
+
+	if buffer.Len() > maxPluginMessageLength {
+		return errors.New("plugin request data is longer than maximum allowed")
+	}
+	packet.Data = append([]byte(nil), buffer.Next(buffer.Len())...)
+
+	return nil
+}
+
+func (packet *PacketLoginOutPluginRequest) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteVarInt(packet.MessageID); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(packet.Channel, 32767); err != nil {
+		return err
+	}
+
+	if len(packet.Data) > maxPluginMessageLength {
+		return errors.New("plugin request data is longer than maximum allowed")
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
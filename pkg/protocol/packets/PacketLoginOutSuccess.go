@@ -6,10 +6,20 @@ import (
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
 )
 
-type PacketLoginOutSuccess struct {
-	UniqueID uuid.UUID
-	Username string
-}
+type (
+	PacketLoginOutSuccess struct {
+		UniqueID   uuid.UUID
+		Username   string
+		Properties []ProfileProperty
+	}
+
+	// ProfileProperty is a property of the player profile, like its skin, only sent from 1.19
+	ProfileProperty struct {
+		Name      string
+		Value     string
+		Signature string
+	}
+)
 
 func (packet *PacketLoginOutSuccess) GetID(proto protocol.Protocol) (int32, error) {
 	return GetID(proto, protocol.Login, protocol.ClientBound, packet)
@@ -40,6 +50,46 @@ func (packet *PacketLoginOutSuccess) Read(proto protocol.Protocol, buffer *bytes
 	}
 	packet.Username = username
 
+	if proto >= protocol.V1_19 {
+		count, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+
+		var properties []ProfileProperty
+		for i := count; i > 0; i-- {
+			var property ProfileProperty
+
+			name, err := buffer.ReadUtf(32767)
+			if err != nil {
+				return err
+			}
+			property.Name = name
+
+			value, err := buffer.ReadUtf(32767)
+			if err != nil {
+				return err
+			}
+			property.Value = value
+
+			signed, err := buffer.ReadBool()
+			if err != nil {
+				return err
+			}
+
+			if signed {
+				signature, err := buffer.ReadUtf(32767)
+				if err != nil {
+					return err
+				}
+				property.Signature = signature
+			}
+
+			properties = append(properties, property)
+		}
+		packet.Properties = properties
+	}
+
 	return nil
 }
 
@@ -58,5 +108,31 @@ func (packet *PacketLoginOutSuccess) Write(proto protocol.Protocol, buffer *byte
 		return err
 	}
 
+	if proto >= protocol.V1_19 {
+		if err := buffer.WriteVarInt(int32(len(packet.Properties))); err != nil {
+			return err
+		}
+
+		for _, property := range packet.Properties {
+			if err := buffer.WriteUtf(property.Name, 32767); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteUtf(property.Value, 32767); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteBool(property.Signature != ""); err != nil {
+				return err
+			}
+
+			if property.Signature != "" {
+				if err := buffer.WriteUtf(property.Signature, 32767); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,58 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayInAbilities struct {
+	Flags        AbilityFlags
+	FlyingSpeed  float32
+	WalkingSpeed float32
+}
+
+func (packet *PacketPlayInAbilities) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInAbilities) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	flags, err := buffer.ReadUint8()
+	if err != nil {
+		return err
+	}
+	packet.Flags = AbilityFlags(flags)
+
+	if proto < protocol.V1_16 {
+		flyingSpeed, err := buffer.ReadFloat32()
+		if err != nil {
+			return err
+		}
+		packet.FlyingSpeed = flyingSpeed
+
+		walkingSpeed, err := buffer.ReadFloat32()
+		if err != nil {
+			return err
+		}
+		packet.WalkingSpeed = walkingSpeed
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayInAbilities) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUint8(uint8(packet.Flags)); err != nil {
+		return err
+	}
+
+	if proto < protocol.V1_16 {
+		if err := buffer.WriteFloat32(packet.FlyingSpeed); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteFloat32(packet.WalkingSpeed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type (
+	// PacketPlayInChatCommand is sent by 1.19+ clients for chat messages starting with a slash, without the slash
+	PacketPlayInChatCommand struct {
+		Command            string
+		ArgumentSignatures []ArgumentSignature
+		ChatSignature
+	}
+
+	ArgumentSignature struct {
+		Name      string
+		Signature []byte
+	}
+)
+
+func (packet *PacketPlayInChatCommand) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInChatCommand) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	command, err := buffer.ReadUtf(256)
+	if err != nil {
+		return err
+	}
+	packet.Command = command
+
+	if err := packet.readTimestamp(buffer); err != nil {
+		return err
+	}
+
+	count, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+
+	if count < 0 || count > 8 {
+		return errors.New("too many argument signatures")
+	}
+
+	var signatures []ArgumentSignature
+	for i := count; i > 0; i-- {
+		var argument ArgumentSignature
+
+		name, err := buffer.ReadUtf(16)
+		if err != nil {
+			return err
+		}
+		argument.Name = name
+
+		if proto >= protocol.V1_19_3 {
+			signature, err := readMessageSignature(buffer)
+			if err != nil {
+				return err
+			}
+			argument.Signature = signature
+		} else {
+			signature, err := buffer.ReadByteArray(messageSignatureLength)
+			if err != nil {
+				return err
+			}
+			argument.Signature = signature
+		}
+
+		signatures = append(signatures, argument)
+	}
+	packet.ArgumentSignatures = signatures
+
+	return packet.readAcknowledgement(proto, buffer)
+}
+
+func (packet *PacketPlayInChatCommand) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.Command, 256); err != nil {
+		return err
+	}
+
+	if err := packet.writeTimestamp(buffer); err != nil {
+		return err
+	}
+
+	if len(packet.ArgumentSignatures) > 8 {
+		return errors.New("too many argument signatures")
+	}
+
+	if err := buffer.WriteVarInt(int32(len(packet.ArgumentSignatures))); err != nil {
+		return err
+	}
+
+	for _, argument := range packet.ArgumentSignatures {
+		if err := buffer.WriteUtf(argument.Name, 16); err != nil {
+			return err
+		}
+
+		if proto >= protocol.V1_19_3 {
+			if err := writeMessageSignature(buffer, argument.Signature); err != nil {
+				return err
+			}
+		} else {
+			if err := buffer.WriteByteArray(argument.Signature, messageSignatureLength); err != nil {
+				return err
+			}
+		}
+	}
+
+	return packet.writeAcknowledgement(proto, buffer)
+}
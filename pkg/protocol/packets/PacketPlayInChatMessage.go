@@ -1,32 +1,288 @@
 package packets
 
 import (
+	"errors"
+	"github.com/google/uuid"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"io"
 )
 
-type PacketPlayInChatMessage struct {
-	Message string
-}
+type (
+	PacketPlayInChatMessage struct {
+		Message string
+		ChatSignature
+	}
+
+	// ChatSignature holds the signing fields 1.19+ clients send along with their messages and commands
+	ChatSignature struct {
+		Timestamp int64
+		Salt      int64
+		// Signature is empty for unsigned messages, and always 256 bytes long for signed ones from 1.19.3
+		Signature     []byte
+		SignedPreview bool
+		// LastSeen and LastReceived acknowledge the messages the client has seen, only sent by 1.19.1 and 1.19.2
+		LastSeen     []SeenMessage
+		LastReceived *SeenMessage
+		// MessageCount and Acknowledged replace LastSeen and LastReceived from 1.19.3
+		MessageCount int32
+		Acknowledged [3]byte
+	}
+
+	SeenMessage struct {
+		Sender    uuid.UUID
+		Signature []byte
+	}
+)
+
+const messageSignatureLength = 256
 
 func (packet *PacketPlayInChatMessage) GetID(proto protocol.Protocol) (int32, error) {
 	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
 }
 
-func (packet *PacketPlayInChatMessage) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+func (packet *PacketPlayInChatMessage) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
 	message, err := buffer.ReadUtf(256)
 	if err != nil {
 		return err
 	}
 	packet.Message = message
 
+	if proto >= protocol.V1_19 {
+		if err := packet.readTimestamp(buffer); err != nil {
+			return err
+		}
+
+		if proto >= protocol.V1_19_3 {
+			hasSignature, err := buffer.ReadBool()
+			if err != nil {
+				return err
+			}
+
+			if hasSignature {
+				signature, err := readMessageSignature(buffer)
+				if err != nil {
+					return err
+				}
+				packet.Signature = signature
+			}
+		} else {
+			signature, err := buffer.ReadByteArray(messageSignatureLength)
+			if err != nil {
+				return err
+			}
+			packet.Signature = signature
+		}
+
+		if err := packet.readAcknowledgement(proto, buffer); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (packet *PacketPlayInChatMessage) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+func (packet *PacketPlayInChatMessage) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
 	if err := buffer.WriteUtf(packet.Message, 256); err != nil {
 		return err
 	}
 
+	if proto >= protocol.V1_19 {
+		if err := packet.writeTimestamp(buffer); err != nil {
+			return err
+		}
+
+		if proto >= protocol.V1_19_3 {
+			if err := buffer.WriteBool(len(packet.Signature) > 0); err != nil {
+				return err
+			}
+
+			if len(packet.Signature) > 0 {
+				if err := writeMessageSignature(buffer, packet.Signature); err != nil {
+					return err
+				}
+			}
+		} else {
+			if err := buffer.WriteByteArray(packet.Signature, messageSignatureLength); err != nil {
+				return err
+			}
+		}
+
+		if err := packet.writeAcknowledgement(proto, buffer); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func (signature *ChatSignature) readTimestamp(buffer *bytes.Buffer) error {
+	timestamp, err := buffer.ReadInt64()
+	if err != nil {
+		return err
+	}
+	signature.Timestamp = timestamp
+
+	salt, err := buffer.ReadInt64()
+	if err != nil {
+		return err
+	}
+	signature.Salt = salt
+
+	return nil
+}
+
+func (signature *ChatSignature) writeTimestamp(buffer *bytes.Buffer) error {
+	if err := buffer.WriteInt64(signature.Timestamp); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt64(signature.Salt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readAcknowledgement reads what follows the signatures, which changed with every 1.19 release
+func (signature *ChatSignature) readAcknowledgement(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_19_3 {
+		messageCount, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		signature.MessageCount = messageCount
+
+		if _, err := io.ReadFull(buffer, signature.Acknowledged[:]); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	signedPreview, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	signature.SignedPreview = signedPreview
+
+	if proto >= protocol.V1_19_1 {
+		count, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+
+		if count < 0 || count > 5 {
+			return errors.New("too many last seen messages")
+		}
+
+		var lastSeen []SeenMessage
+		for i := count; i > 0; i-- {
+			message, err := readSeenMessage(buffer)
+			if err != nil {
+				return err
+			}
+			lastSeen = append(lastSeen, message)
+		}
+		signature.LastSeen = lastSeen
+
+		hasLastReceived, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if hasLastReceived {
+			message, err := readSeenMessage(buffer)
+			if err != nil {
+				return err
+			}
+			signature.LastReceived = &message
+		}
+	}
+
+	return nil
+}
+
+func (signature *ChatSignature) writeAcknowledgement(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_19_3 {
+		if err := buffer.WriteVarInt(signature.MessageCount); err != nil {
+			return err
+		}
+
+		if _, err := buffer.Write(signature.Acknowledged[:]); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if err := buffer.WriteBool(signature.SignedPreview); err != nil {
+		return err
+	}
+
+	if proto >= protocol.V1_19_1 {
+		if len(signature.LastSeen) > 5 {
+			return errors.New("too many last seen messages")
+		}
+
+		if err := buffer.WriteVarInt(int32(len(signature.LastSeen))); err != nil {
+			return err
+		}
+
+		for _, message := range signature.LastSeen {
+			if err := writeSeenMessage(buffer, message); err != nil {
+				return err
+			}
+		}
+
+		if err := buffer.WriteBool(signature.LastReceived != nil); err != nil {
+			return err
+		}
+
+		if signature.LastReceived != nil {
+			if err := writeSeenMessage(buffer, *signature.LastReceived); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func readSeenMessage(buffer *bytes.Buffer) (SeenMessage, error) {
+	sender, err := buffer.ReadUUID()
+	if err != nil {
+		return SeenMessage{}, err
+	}
+
+	signature, err := buffer.ReadByteArray(messageSignatureLength)
+	if err != nil {
+		return SeenMessage{}, err
+	}
+
+	return SeenMessage{Sender: sender, Signature: signature}, nil
+}
+
+func writeSeenMessage(buffer *bytes.Buffer, message SeenMessage) error {
+	if err := buffer.WriteUUID(message.Sender); err != nil {
+		return err
+	}
+
+	return buffer.WriteByteArray(message.Signature, messageSignatureLength)
+}
+
+// readMessageSignature reads the fixed length signatures used from 1.19.3
+func readMessageSignature(buffer *bytes.Buffer) ([]byte, error) {
+	var signature = make([]byte, messageSignatureLength)
+	if _, err := io.ReadFull(buffer, signature); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+func writeMessageSignature(buffer *bytes.Buffer, signature []byte) error {
+	if len(signature) != messageSignatureLength {
+		return errors.New("invalid message signature length")
+	}
+
+	_, err := buffer.Write(signature)
+	return err
+}
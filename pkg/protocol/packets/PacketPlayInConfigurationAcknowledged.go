@@ -0,0 +1,21 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketPlayInConfigurationAcknowledged is the answer to a start configuration, the client only expects configuration packets after sending it
+type PacketPlayInConfigurationAcknowledged struct{}
+
+func (packet *PacketPlayInConfigurationAcknowledged) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInConfigurationAcknowledged) Read(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
+
+func (packet *PacketPlayInConfigurationAcknowledged) Write(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
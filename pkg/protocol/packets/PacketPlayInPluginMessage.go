@@ -0,0 +1,48 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketPlayInPluginMessage channels are translated the same way as PacketPlayOutPluginMessage ones
+type PacketPlayInPluginMessage struct {
+	Channel string
+	Data    []byte
+}
+
+func (packet *PacketPlayInPluginMessage) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInPluginMessage) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	channel, err := buffer.ReadUtf(maxChannelLength(proto))
+	if err != nil {
+		return err
+	}
+	packet.Channel = FromLegacyChannel(channel, proto)
+
+	if buffer.Len() > 32767 {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+	packet.Data = append([]byte(nil), buffer.Next(buffer.Len())...)
+
+	return nil
+}
+
+func (packet *PacketPlayInPluginMessage) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(ToLegacyChannel(packet.Channel, proto), maxChannelLength(proto)); err != nil {
+		return err
+	}
+
+	if len(packet.Data) > 32767 {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
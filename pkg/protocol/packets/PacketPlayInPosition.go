@@ -0,0 +1,63 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayInPosition struct {
+	X, Y, Z  float64
+	OnGround bool
+}
+
+func (packet *PacketPlayInPosition) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInPosition) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	x, err := buffer.ReadFloat64()
+	if err != nil {
+		return err
+	}
+	packet.X = x
+
+	y, err := buffer.ReadFloat64()
+	if err != nil {
+		return err
+	}
+	packet.Y = y
+
+	z, err := buffer.ReadFloat64()
+	if err != nil {
+		return err
+	}
+	packet.Z = z
+
+	onGround, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.OnGround = onGround
+
+	return nil
+}
+
+func (packet *PacketPlayInPosition) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteFloat64(packet.X); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat64(packet.Y); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat64(packet.Z); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.OnGround); err != nil {
+		return err
+	}
+
+	return nil
+}
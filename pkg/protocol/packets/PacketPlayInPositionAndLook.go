@@ -0,0 +1,84 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayInPositionAndLook struct {
+	X, Y, Z    float64
+	Yaw, Pitch float32
+	OnGround   bool
+}
+
+func (packet *PacketPlayInPositionAndLook) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInPositionAndLook) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	x, err := buffer.ReadFloat64()
+	if err != nil {
+		return err
+	}
+	packet.X = x
+
+	y, err := buffer.ReadFloat64()
+	if err != nil {
+		return err
+	}
+	packet.Y = y
+
+	z, err := buffer.ReadFloat64()
+	if err != nil {
+		return err
+	}
+	packet.Z = z
+
+	yaw, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.Yaw = yaw
+
+	pitch, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.Pitch = pitch
+
+	onGround, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.OnGround = onGround
+
+	return nil
+}
+
+func (packet *PacketPlayInPositionAndLook) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteFloat64(packet.X); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat64(packet.Y); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat64(packet.Z); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.Yaw); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.Pitch); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.OnGround); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayInTabComplete struct {
+	TransactionID int32
+	Text          string
+	AssumeCommand bool
+	HasPosition   bool
+	LookedAtBlock int64
+}
+
+func (packet *PacketPlayInTabComplete) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ServerBound, packet)
+}
+
+func (packet *PacketPlayInTabComplete) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_13 {
+		transactionID, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.TransactionID = transactionID
+
+		text, err := buffer.ReadUtf(32500)
+		if err != nil {
+			return err
+		}
+		packet.Text = text
+
+		return nil
+	}
+
+	text, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.Text = text
+
+	if proto >= protocol.V1_9 {
+		assumeCommand, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+		packet.AssumeCommand = assumeCommand
+	}
+
+	hasPosition, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.HasPosition = hasPosition
+
+	if packet.HasPosition {
+		lookedAtBlock, err := buffer.ReadInt64()
+		if err != nil {
+			return err
+		}
+		packet.LookedAtBlock = lookedAtBlock
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayInTabComplete) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_13 {
+		if err := buffer.WriteVarInt(packet.TransactionID); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteUtf(packet.Text, 32500); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := buffer.WriteUtf(packet.Text, 32767); err != nil {
+		return err
+	}
+
+	if proto >= protocol.V1_9 {
+		if err := buffer.WriteBool(packet.AssumeCommand); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteBool(packet.HasPosition); err != nil {
+		return err
+	}
+
+	if packet.HasPosition {
+		if err := buffer.WriteInt64(packet.LookedAtBlock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type (
+	PacketPlayOutAbilities struct {
+		Flags       AbilityFlags
+		FlyingSpeed float32
+		FieldOfView float32
+	}
+
+	AbilityFlags uint8
+)
+
+const (
+	InvulnerableAbilityFlag AbilityFlags = 1 << iota
+	FlyingAbilityFlag
+	AllowFlyingAbilityFlag
+	CreativeModeAbilityFlag
+)
+
+func (packet *PacketPlayOutAbilities) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutAbilities) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	flags, err := buffer.ReadUint8()
+	if err != nil {
+		return err
+	}
+	packet.Flags = AbilityFlags(flags)
+
+	flyingSpeed, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.FlyingSpeed = flyingSpeed
+
+	fieldOfView, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.FieldOfView = fieldOfView
+
+	return nil
+}
+
+func (packet *PacketPlayOutAbilities) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUint8(uint8(packet.Flags)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.FlyingSpeed); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.FieldOfView); err != nil {
+		return err
+	}
+
+	return nil
+}
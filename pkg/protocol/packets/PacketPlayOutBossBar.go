@@ -0,0 +1,235 @@
+package packets
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type (
+	PacketPlayOutBossBar struct {
+		UniqueID uuid.UUID
+		Action   BossBarAction
+		Title    []chat.Component
+		Health   float32
+		Color    BossBarColor
+		Overlay  BossBarOverlay
+		Flags    BossBarFlags
+	}
+
+	BossBarAction  int32
+	BossBarColor   int32
+	BossBarOverlay int32
+	BossBarFlags   uint8
+)
+
+const (
+	AddBossBarAction BossBarAction = iota
+	RemoveBossBarAction
+	UpdateHealthBossBarAction
+	UpdateTitleBossBarAction
+	UpdateStyleBossBarAction
+	UpdateFlagsBossBarAction
+)
+
+const (
+	PinkBossBarColor BossBarColor = iota
+	BlueBossBarColor
+	RedBossBarColor
+	GreenBossBarColor
+	YellowBossBarColor
+	PurpleBossBarColor
+	WhiteBossBarColor
+)
+
+const (
+	ProgressBossBarOverlay BossBarOverlay = iota
+	Notched6BossBarOverlay
+	Notched10BossBarOverlay
+	Notched12BossBarOverlay
+	Notched20BossBarOverlay
+)
+
+const (
+	DarkenSkyBossBarFlag BossBarFlags = 1 << iota
+	PlayMusicBossBarFlag
+	CreateFogBossBarFlag
+)
+
+func (packet *PacketPlayOutBossBar) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutBossBar) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	uniqueID, err := buffer.ReadUUID()
+	if err != nil {
+		return err
+	}
+	packet.UniqueID = uniqueID
+
+	action, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.Action = BossBarAction(action)
+
+	switch packet.Action {
+	case AddBossBarAction:
+		if err := packet.readTitle(buffer); err != nil {
+			return err
+		}
+
+		if err := packet.readHealth(buffer); err != nil {
+			return err
+		}
+
+		if err := packet.readStyle(buffer); err != nil {
+			return err
+		}
+
+		if err := packet.readFlags(buffer); err != nil {
+			return err
+		}
+	case RemoveBossBarAction:
+	case UpdateHealthBossBarAction:
+		if err := packet.readHealth(buffer); err != nil {
+			return err
+		}
+	case UpdateTitleBossBarAction:
+		if err := packet.readTitle(buffer); err != nil {
+			return err
+		}
+	case UpdateStyleBossBarAction:
+		if err := packet.readStyle(buffer); err != nil {
+			return err
+		}
+	case UpdateFlagsBossBarAction:
+		if err := packet.readFlags(buffer); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("received invalid boss bar action %d", action)
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutBossBar) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUUID(packet.UniqueID); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteVarInt(int32(packet.Action)); err != nil {
+		return err
+	}
+
+	switch packet.Action {
+	case AddBossBarAction:
+		if err := packet.writeTitle(buffer); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteFloat32(packet.Health); err != nil {
+			return err
+		}
+
+		if err := packet.writeStyle(buffer); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteUint8(uint8(packet.Flags)); err != nil {
+			return err
+		}
+	case RemoveBossBarAction:
+	case UpdateHealthBossBarAction:
+		if err := buffer.WriteFloat32(packet.Health); err != nil {
+			return err
+		}
+	case UpdateTitleBossBarAction:
+		if err := packet.writeTitle(buffer); err != nil {
+			return err
+		}
+	case UpdateStyleBossBarAction:
+		if err := packet.writeStyle(buffer); err != nil {
+			return err
+		}
+	case UpdateFlagsBossBarAction:
+		if err := buffer.WriteUint8(uint8(packet.Flags)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid boss bar action %d", packet.Action)
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutBossBar) readTitle(buffer *bytes.Buffer) error {
+	titleStr, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+
+	title, err := chat.FromJSON([]byte(titleStr))
+	if err != nil {
+		return err
+	}
+	packet.Title = title
+
+	return nil
+}
+
+func (packet *PacketPlayOutBossBar) readHealth(buffer *bytes.Buffer) error {
+	health, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.Health = health
+
+	return nil
+}
+
+func (packet *PacketPlayOutBossBar) readStyle(buffer *bytes.Buffer) error {
+	color, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.Color = BossBarColor(color)
+
+	overlay, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.Overlay = BossBarOverlay(overlay)
+
+	return nil
+}
+
+func (packet *PacketPlayOutBossBar) readFlags(buffer *bytes.Buffer) error {
+	flags, err := buffer.ReadUint8()
+	if err != nil {
+		return err
+	}
+	packet.Flags = BossBarFlags(flags)
+
+	return nil
+}
+
+func (packet *PacketPlayOutBossBar) writeTitle(buffer *bytes.Buffer) error {
+	title, err := chat.ToJSON(packet.Title)
+	if err != nil {
+		return err
+	}
+
+	return buffer.WriteUtf(string(title), 32767)
+}
+
+func (packet *PacketPlayOutBossBar) writeStyle(buffer *bytes.Buffer) error {
+	if err := buffer.WriteVarInt(int32(packet.Color)); err != nil {
+		return err
+	}
+
+	return buffer.WriteVarInt(int32(packet.Overlay))
+}
@@ -0,0 +1,62 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type (
+	PacketPlayOutChangeGameState struct {
+		Reason GameStateReason
+		Value  float32
+	}
+
+	GameStateReason uint8
+)
+
+const (
+	NoRespawnBlockReason GameStateReason = iota
+	EndRainingReason
+	BeginRainingReason
+	ChangeGameModeReason
+	WinGameReason
+	DemoEventReason
+	ArrowHitPlayerReason
+	RainLevelChangeReason
+	ThunderLevelChangeReason
+	PufferfishStingReason
+	ElderGuardianAppearanceReason
+	EnableRespawnScreenReason
+)
+
+func (packet *PacketPlayOutChangeGameState) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutChangeGameState) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	reason, err := buffer.ReadUint8()
+	if err != nil {
+		return err
+	}
+	packet.Reason = GameStateReason(reason)
+
+	value, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.Value = value
+
+	return nil
+}
+
+func (packet *PacketPlayOutChangeGameState) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUint8(uint8(packet.Reason)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -7,12 +7,16 @@ import (
 	"github.com/r4g3baby/mcserver/pkg/util/chat"
 )
 
+// PacketPlayOutChatMessage is sent as a system message from 1.19, which has no sender
 type PacketPlayOutChatMessage struct {
 	Message  []chat.Component
 	Position int8
 	Sender   uuid.UUID
 }
 
+// ActionBarChatPosition shows the message above the hotbar instead of in the chat
+const ActionBarChatPosition int8 = 2
+
 func (packet *PacketPlayOutChatMessage) GetID(proto protocol.Protocol) (int32, error) {
 	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
 }
@@ -29,6 +33,26 @@ func (packet *PacketPlayOutChatMessage) Read(proto protocol.Protocol, buffer *by
 	}
 	packet.Message = message
 
+	if proto >= protocol.V1_19_1 {
+		overlay, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		packet.Position = 1
+		if overlay {
+			packet.Position = ActionBarChatPosition
+		}
+		return nil
+	} else if proto >= protocol.V1_19 {
+		position, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.Position = int8(position)
+		return nil
+	}
+
 	position, err := buffer.ReadInt8()
 	if err != nil {
 		return err
@@ -56,6 +80,17 @@ func (packet *PacketPlayOutChatMessage) Write(proto protocol.Protocol, buffer *b
 		return err
 	}
 
+	if proto >= protocol.V1_19_1 {
+		return buffer.WriteBool(packet.Position == ActionBarChatPosition)
+	} else if proto >= protocol.V1_19 {
+		// Only system and game info messages are left, so player messages are sent as system ones
+		position := int32(1)
+		if packet.Position == ActionBarChatPosition {
+			position = int32(ActionBarChatPosition)
+		}
+		return buffer.WriteVarInt(position)
+	}
+
 	if err := buffer.WriteInt8(packet.Position); err != nil {
 		return err
 	}
@@ -1,26 +1,57 @@
 package packets
 
 import (
+	"errors"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
 	"github.com/r4g3baby/mcserver/pkg/util/nbt"
+	"io"
 )
 
-type PacketPlayOutChunkData struct {
-	ChunkX, ChunkZ int32
-	FullChunk      bool
-	PrimaryBit     int32
-	Heightmaps     nbt.Tag
-	Biomes         []int32
-	Data           []byte
-	BlockEntities  []nbt.Tag
-}
+type (
+	PacketPlayOutChunkData struct {
+		ChunkX, ChunkZ int32
+		// FullChunk is only sent before 1.17, which always sends full chunks
+		FullChunk  bool
+		PrimaryBit int32
+		Heightmaps nbt.Tag
+		// Biomes are part of the section data from 1.18
+		Biomes        []int32
+		Data          []byte
+		BlockEntities []ChunkBlockEntity
+		// Light is only sent along with the chunk from 1.18
+		Light ChunkLight
+	}
+
+	// ChunkBlockEntity holds a block entity, its position and type are part of Data before 1.18
+	ChunkBlockEntity struct {
+		X, Z uint8
+		Y    int16
+		Type int32
+		Data nbt.Tag
+	}
+
+	// ChunkLight holds the light of every section, plus the ones right below and above the world
+	ChunkLight struct {
+		// TrustEdges is no longer sent from 1.20
+		TrustEdges          bool
+		SkyLightMask        int64
+		BlockLightMask      int64
+		EmptySkyLightMask   int64
+		EmptyBlockLightMask int64
+		SkyLight            [][]byte
+		BlockLight          [][]byte
+	}
+)
+
+// lightArrayLength is the size of the light data of a section, half a byte per block
+const lightArrayLength = 2048
 
 func (packet *PacketPlayOutChunkData) GetID(proto protocol.Protocol) (int32, error) {
 	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
 }
 
-func (packet *PacketPlayOutChunkData) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+func (packet *PacketPlayOutChunkData) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
 	chunkX, err := buffer.ReadInt32()
 	if err != nil {
 		return err
@@ -33,25 +64,33 @@ func (packet *PacketPlayOutChunkData) Read(_ protocol.Protocol, buffer *bytes.Bu
 	}
 	packet.ChunkZ = chunkZ
 
-	fullChunk, err := buffer.ReadBool()
-	if err != nil {
-		return err
-	}
-	packet.FullChunk = fullChunk
+	if proto < protocol.V1_17 {
+		fullChunk, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+		packet.FullChunk = fullChunk
 
-	primaryBit, err := buffer.ReadVarInt()
-	if err != nil {
-		return err
+		primaryBit, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.PrimaryBit = primaryBit
+	} else if proto < protocol.V1_18 {
+		primaryBit, err := readBitSet(buffer)
+		if err != nil {
+			return err
+		}
+		packet.PrimaryBit = int32(primaryBit)
 	}
-	packet.PrimaryBit = primaryBit
 
-	_, heightmaps, err := nbt.Read(buffer)
+	heightmaps, err := readNBT(proto, buffer)
 	if err != nil {
 		return err
 	}
 	packet.Heightmaps = heightmaps
 
-	if packet.FullChunk {
+	if (proto < protocol.V1_17 && packet.FullChunk) || (proto >= protocol.V1_17 && proto < protocol.V1_18) {
 		biomesCount, err := buffer.ReadVarInt()
 		if err != nil {
 			return err
@@ -73,9 +112,12 @@ func (packet *PacketPlayOutChunkData) Read(_ protocol.Protocol, buffer *bytes.Bu
 		return err
 	}
 
+	if size < 0 || int(size) > buffer.Len() {
+		return errors.New("invalid chunk data size")
+	}
+
 	var data = make([]byte, size)
-	_, err = buffer.Read(data)
-	if err != nil {
+	if _, err := io.ReadFull(buffer, data); err != nil {
 		return err
 	}
 	packet.Data = data
@@ -85,20 +127,48 @@ func (packet *PacketPlayOutChunkData) Read(_ protocol.Protocol, buffer *bytes.Bu
 		return err
 	}
 
-	var blockEntities []nbt.Tag
+	var blockEntities []ChunkBlockEntity
 	for i := blockEntitiesCount; i > 0; i-- {
-		_, blockEntity, err := nbt.Read(buffer)
+		var blockEntity ChunkBlockEntity
+
+		if proto >= protocol.V1_18 {
+			packedXZ, err := buffer.ReadUint8()
+			if err != nil {
+				return err
+			}
+			blockEntity.X, blockEntity.Z = packedXZ>>4, packedXZ&0x0F
+
+			y, err := buffer.ReadInt16()
+			if err != nil {
+				return err
+			}
+			blockEntity.Y = y
+
+			typ, err := buffer.ReadVarInt()
+			if err != nil {
+				return err
+			}
+			blockEntity.Type = typ
+		}
+
+		data, err := readNBT(proto, buffer)
 		if err != nil {
 			return err
 		}
+		blockEntity.Data = data
+
 		blockEntities = append(blockEntities, blockEntity)
 	}
 	packet.BlockEntities = blockEntities
 
+	if proto >= protocol.V1_18 {
+		return packet.Light.read(proto, buffer)
+	}
+
 	return nil
 }
 
-func (packet *PacketPlayOutChunkData) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+func (packet *PacketPlayOutChunkData) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
 	if err := buffer.WriteInt32(packet.ChunkX); err != nil {
 		return err
 	}
@@ -107,19 +177,25 @@ func (packet *PacketPlayOutChunkData) Write(_ protocol.Protocol, buffer *bytes.B
 		return err
 	}
 
-	if err := buffer.WriteBool(packet.FullChunk); err != nil {
-		return err
-	}
+	if proto < protocol.V1_17 {
+		if err := buffer.WriteBool(packet.FullChunk); err != nil {
+			return err
+		}
 
-	if err := buffer.WriteVarInt(packet.PrimaryBit); err != nil {
-		return err
+		if err := buffer.WriteVarInt(packet.PrimaryBit); err != nil {
+			return err
+		}
+	} else if proto < protocol.V1_18 {
+		if err := writeBitSet(buffer, int64(packet.PrimaryBit)); err != nil {
+			return err
+		}
 	}
 
-	if err := nbt.Write(buffer, "", packet.Heightmaps); err != nil {
+	if err := writeNBT(proto, buffer, packet.Heightmaps); err != nil {
 		return err
 	}
 
-	if packet.FullChunk {
+	if (proto < protocol.V1_17 && packet.FullChunk) || (proto >= protocol.V1_17 && proto < protocol.V1_18) {
 		if err := buffer.WriteVarInt(int32(len(packet.Biomes))); err != nil {
 			return err
 		}
@@ -144,10 +220,143 @@ func (packet *PacketPlayOutChunkData) Write(_ protocol.Protocol, buffer *bytes.B
 	}
 
 	for _, blockEntity := range packet.BlockEntities {
-		if err := nbt.Write(buffer, "", blockEntity); err != nil {
+		if proto >= protocol.V1_18 {
+			if err := buffer.WriteUint8(blockEntity.X<<4 | blockEntity.Z&0x0F); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteInt16(blockEntity.Y); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteVarInt(blockEntity.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := writeNBT(proto, buffer, blockEntity.Data); err != nil {
+			return err
+		}
+	}
+
+	if proto >= protocol.V1_18 {
+		return packet.Light.write(proto, buffer)
+	}
+
+	return nil
+}
+
+func (light *ChunkLight) read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto < protocol.V1_20 {
+		trustEdges, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+		light.TrustEdges = trustEdges
+	}
+
+	for _, mask := range []*int64{&light.SkyLightMask, &light.BlockLightMask, &light.EmptySkyLightMask, &light.EmptyBlockLightMask} {
+		value, err := readBitSet(buffer)
+		if err != nil {
+			return err
+		}
+		*mask = value
+	}
+
+	for _, arrays := range []*[][]byte{&light.SkyLight, &light.BlockLight} {
+		count, err := buffer.ReadVarInt()
+		if err != nil {
 			return err
 		}
+
+		// Every section plus the ones below and above the world fit in a single long
+		if count < 0 || count > 64 {
+			return errors.New("too many light arrays")
+		}
+
+		var values [][]byte
+		for i := count; i > 0; i-- {
+			value, err := buffer.ReadByteArray(lightArrayLength)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		*arrays = values
 	}
 
 	return nil
 }
+
+func (light *ChunkLight) write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto < protocol.V1_20 {
+		if err := buffer.WriteBool(light.TrustEdges); err != nil {
+			return err
+		}
+	}
+
+	for _, mask := range []int64{light.SkyLightMask, light.BlockLightMask, light.EmptySkyLightMask, light.EmptyBlockLightMask} {
+		if err := writeBitSet(buffer, mask); err != nil {
+			return err
+		}
+	}
+
+	for _, arrays := range [][][]byte{light.SkyLight, light.BlockLight} {
+		if err := buffer.WriteVarInt(int32(len(arrays))); err != nil {
+			return err
+		}
+
+		for _, value := range arrays {
+			if err := buffer.WriteByteArray(value, lightArrayLength); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readBitSet reads a bit set that must fit in a single long, which is enough for the sections of a chunk
+func readBitSet(buffer *bytes.Buffer) (int64, error) {
+	length, err := buffer.ReadVarInt()
+	if err != nil {
+		return 0, err
+	}
+
+	switch length {
+	case 0:
+		return 0, nil
+	case 1:
+		return buffer.ReadInt64()
+	default:
+		return 0, errors.New("bit sets longer than a long are not supported")
+	}
+}
+
+func writeBitSet(buffer *bytes.Buffer, value int64) error {
+	if value == 0 {
+		return buffer.WriteVarInt(0)
+	}
+
+	if err := buffer.WriteVarInt(1); err != nil {
+		return err
+	}
+	return buffer.WriteInt64(value)
+}
+
+// readNBT reads a tag sent over the network, which lost its root name in 1.20.2
+func readNBT(proto protocol.Protocol, buffer *bytes.Buffer) (nbt.Tag, error) {
+	if proto >= protocol.V1_20_2 {
+		return nbt.ReadUnnamed(buffer)
+	}
+
+	_, tag, err := nbt.Read(buffer)
+	return tag, err
+}
+
+func writeNBT(proto protocol.Protocol, buffer *bytes.Buffer, tag nbt.Tag) error {
+	if proto >= protocol.V1_20_2 {
+		return nbt.WriteUnnamed(buffer, tag)
+	}
+	return nbt.Write(buffer, "", tag)
+}
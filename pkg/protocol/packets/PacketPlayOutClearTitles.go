@@ -0,0 +1,33 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketPlayOutClearTitles hides the current title, resetting its texts and times as well when Reset is set
+type PacketPlayOutClearTitles struct {
+	Reset bool
+}
+
+func (packet *PacketPlayOutClearTitles) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutClearTitles) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	reset, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.Reset = reset
+
+	return nil
+}
+
+func (packet *PacketPlayOutClearTitles) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteBool(packet.Reset); err != nil {
+		return err
+	}
+
+	return nil
+}
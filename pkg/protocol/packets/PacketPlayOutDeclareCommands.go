@@ -0,0 +1,270 @@
+package packets
+
+import (
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"io"
+)
+
+type (
+	PacketPlayOutDeclareCommands struct {
+		Nodes     []CommandNode
+		RootIndex int32
+	}
+
+	CommandNode struct {
+		Flags        CommandNodeFlags
+		Children     []int32
+		RedirectNode int32
+		Name         string
+		Parser       string
+		// Properties holds the parser properties, already encoded
+		Properties      []byte
+		SuggestionsType string
+	}
+
+	CommandNodeFlags uint8
+)
+
+const (
+	RootCommandNodeType     CommandNodeFlags = 0x00
+	LiteralCommandNodeType  CommandNodeFlags = 0x01
+	ArgumentCommandNodeType CommandNodeFlags = 0x02
+	CommandNodeTypeMask     CommandNodeFlags = 0x03
+
+	ExecutableCommandNodeFlag      CommandNodeFlags = 0x04
+	RedirectCommandNodeFlag        CommandNodeFlags = 0x08
+	SuggestionsTypeCommandNodeFlag CommandNodeFlags = 0x10
+)
+
+// commandParsers lists the parsers by their registry id, which replaced their names from 1.19.
+// Only the ones that kept the same id up to 1.20.1 are listed.
+var commandParsers = []string{
+	"brigadier:bool", "brigadier:float", "brigadier:double", "brigadier:integer", "brigadier:long", "brigadier:string",
+	"minecraft:entity", "minecraft:game_profile", "minecraft:block_pos", "minecraft:column_pos", "minecraft:vec3",
+	"minecraft:vec2", "minecraft:block_state", "minecraft:block_predicate", "minecraft:item_stack",
+	"minecraft:item_predicate", "minecraft:color", "minecraft:component", "minecraft:message",
+	"minecraft:nbt_compound_tag", "minecraft:nbt_tag", "minecraft:nbt_path", "minecraft:objective",
+	"minecraft:objective_criteria", "minecraft:operation", "minecraft:particle", "minecraft:angle",
+	"minecraft:rotation", "minecraft:scoreboard_slot", "minecraft:score_holder", "minecraft:swizzle",
+	"minecraft:team", "minecraft:item_slot", "minecraft:resource_location",
+}
+
+func (packet *PacketPlayOutDeclareCommands) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutDeclareCommands) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	count, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+
+	var nodes []CommandNode
+	for i := count; i > 0; i-- {
+		var node CommandNode
+
+		flags, err := buffer.ReadUint8()
+		if err != nil {
+			return err
+		}
+		node.Flags = CommandNodeFlags(flags)
+
+		childrenCount, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+
+		for j := childrenCount; j > 0; j-- {
+			child, err := buffer.ReadVarInt()
+			if err != nil {
+				return err
+			}
+			node.Children = append(node.Children, child)
+		}
+
+		if node.Flags&RedirectCommandNodeFlag != 0 {
+			redirectNode, err := buffer.ReadVarInt()
+			if err != nil {
+				return err
+			}
+			node.RedirectNode = redirectNode
+		}
+
+		nodeType := node.Flags & CommandNodeTypeMask
+		if nodeType == LiteralCommandNodeType || nodeType == ArgumentCommandNodeType {
+			name, err := buffer.ReadUtf(32767)
+			if err != nil {
+				return err
+			}
+			node.Name = name
+		}
+
+		if nodeType == ArgumentCommandNodeType {
+			parser, err := readParser(proto, buffer)
+			if err != nil {
+				return err
+			}
+			node.Parser = parser
+
+			properties, err := readParserProperties(parser, buffer)
+			if err != nil {
+				return err
+			}
+			node.Properties = properties
+
+			if node.Flags&SuggestionsTypeCommandNodeFlag != 0 {
+				suggestionsType, err := buffer.ReadUtf(32767)
+				if err != nil {
+					return err
+				}
+				node.SuggestionsType = suggestionsType
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+	packet.Nodes = nodes
+
+	rootIndex, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.RootIndex = rootIndex
+
+	return nil
+}
+
+func (packet *PacketPlayOutDeclareCommands) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteVarInt(int32(len(packet.Nodes))); err != nil {
+		return err
+	}
+
+	for _, node := range packet.Nodes {
+		if err := buffer.WriteUint8(uint8(node.Flags)); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteVarInt(int32(len(node.Children))); err != nil {
+			return err
+		}
+
+		for _, child := range node.Children {
+			if err := buffer.WriteVarInt(child); err != nil {
+				return err
+			}
+		}
+
+		if node.Flags&RedirectCommandNodeFlag != 0 {
+			if err := buffer.WriteVarInt(node.RedirectNode); err != nil {
+				return err
+			}
+		}
+
+		nodeType := node.Flags & CommandNodeTypeMask
+		if nodeType == LiteralCommandNodeType || nodeType == ArgumentCommandNodeType {
+			if err := buffer.WriteUtf(node.Name, 32767); err != nil {
+				return err
+			}
+		}
+
+		if nodeType == ArgumentCommandNodeType {
+			if err := writeParser(proto, buffer, node.Parser); err != nil {
+				return err
+			}
+
+			if _, err := buffer.Write(node.Properties); err != nil {
+				return err
+			}
+
+			if node.Flags&SuggestionsTypeCommandNodeFlag != 0 {
+				if err := buffer.WriteUtf(node.SuggestionsType, 32767); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := buffer.WriteVarInt(packet.RootIndex); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func readParser(proto protocol.Protocol, buffer *bytes.Buffer) (string, error) {
+	if proto < protocol.V1_19 {
+		return buffer.ReadUtf(32767)
+	}
+
+	parserID, err := buffer.ReadVarInt()
+	if err != nil {
+		return "", err
+	}
+
+	if parserID < 0 || int(parserID) >= len(commandParsers) {
+		return "", fmt.Errorf("unknown command parser %d", parserID)
+	}
+	return commandParsers[parserID], nil
+}
+
+func writeParser(proto protocol.Protocol, buffer *bytes.Buffer, parser string) error {
+	if proto < protocol.V1_19 {
+		return buffer.WriteUtf(parser, 32767)
+	}
+
+	for parserID, name := range commandParsers {
+		if name == parser {
+			return buffer.WriteVarInt(int32(parserID))
+		}
+	}
+	return fmt.Errorf("command parser %s has no id in protocol %d", parser, proto)
+}
+
+// readParserProperties reads the raw properties of the parsers that have any,
+// since their length can't be known without understanding each parser.
+func readParserProperties(parser string, buffer *bytes.Buffer) ([]byte, error) {
+	properties := bytes.NewBuffer(nil)
+	switch parser {
+	case "brigadier:integer", "brigadier:float", "brigadier:double", "brigadier:long":
+		flags, err := buffer.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		_ = properties.WriteUint8(flags)
+
+		size := 4
+		if parser == "brigadier:double" || parser == "brigadier:long" {
+			size = 8
+		}
+		for _, flag := range []uint8{0x01, 0x02} {
+			if flags&flag != 0 {
+				bound := buffer.Next(size)
+				if len(bound) != size {
+					return nil, io.ErrUnexpectedEOF
+				}
+				_, _ = properties.Write(bound)
+			}
+		}
+	case "brigadier:string":
+		mode, err := buffer.ReadVarInt()
+		if err != nil {
+			return nil, err
+		}
+		_ = properties.WriteVarInt(mode)
+	case "minecraft:entity", "minecraft:score_holder":
+		flags, err := buffer.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		_ = properties.WriteUint8(flags)
+	case "minecraft:range":
+		decimals, err := buffer.ReadBool()
+		if err != nil {
+			return nil, err
+		}
+		_ = properties.WriteBool(decimals)
+	}
+	return properties.Bytes(), nil
+}
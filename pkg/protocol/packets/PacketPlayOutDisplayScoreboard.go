@@ -0,0 +1,53 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type (
+	PacketPlayOutDisplayScoreboard struct {
+		Position  DisplaySlot
+		ScoreName string
+	}
+
+	DisplaySlot int8
+)
+
+const (
+	ListDisplaySlot DisplaySlot = iota
+	SidebarDisplaySlot
+	BelowNameDisplaySlot
+)
+
+func (packet *PacketPlayOutDisplayScoreboard) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutDisplayScoreboard) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	position, err := buffer.ReadInt8()
+	if err != nil {
+		return err
+	}
+	packet.Position = DisplaySlot(position)
+
+	scoreName, err := buffer.ReadUtf(16)
+	if err != nil {
+		return err
+	}
+	packet.ScoreName = scoreName
+
+	return nil
+}
+
+func (packet *PacketPlayOutDisplayScoreboard) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteInt8(int8(packet.Position)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(packet.ScoreName, 16); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -8,24 +8,31 @@ import (
 
 type (
 	PacketPlayOutJoinGame struct {
-		EntityID         int32
-		Hardcore         bool
-		Gamemode         uint8
-		PreviousGamemode int8
-		WorldNames       []string
-		DimensionCodec   protocol.DimensionCodec
-		Dimension        protocol.Dimension
-		WorldName        string
-		DimensionID      int8
-		Difficulty       uint8
-		HashedSeed       int64
-		MaxPlayers       int32
-		LevelType        string
-		ViewDistance     int32
-		ReducedDebug     bool
-		RespawnScreen    bool
-		IsDebug          bool
-		IsFlat           bool
+		EntityID           int32
+		Hardcore           bool
+		Gamemode           uint8
+		PreviousGamemode   int8
+		WorldNames         []string
+		DimensionCodec     protocol.DimensionCodec
+		Dimension          protocol.Dimension
+		WorldName          string
+		DimensionID        int8
+		Difficulty         uint8
+		HashedSeed         int64
+		MaxPlayers         int32
+		LevelType          string
+		ViewDistance       int32
+		SimulationDistance int32
+		ReducedDebug       bool
+		RespawnScreen      bool
+		IsDebug            bool
+		IsFlat             bool
+		// DeathDimension and DeathPosition are only sent from 1.19, when the dimension isn't empty
+		DeathDimension string
+		DeathPosition  int64
+		PortalCooldown int32
+		// DoLimitedCrafting is only sent from 1.20.2
+		DoLimitedCrafting bool
 	}
 )
 
@@ -34,6 +41,10 @@ func (packet *PacketPlayOutJoinGame) GetID(proto protocol.Protocol) (int32, erro
 }
 
 func (packet *PacketPlayOutJoinGame) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_20_2 {
+		return packet.readWithoutCodec(buffer)
+	}
+
 	entityID, err := buffer.ReadInt32()
 	if err != nil {
 		return err
@@ -86,7 +97,20 @@ func (packet *PacketPlayOutJoinGame) Read(proto protocol.Protocol, buffer *bytes
 		}
 		packet.DimensionCodec = dimensionCodec
 
-		if proto >= protocol.V1_16_2 {
+		if proto >= protocol.V1_19 {
+			dimensionType, err := buffer.ReadUtf(32767)
+			if err != nil {
+				return err
+			}
+
+			packet.Dimension = protocol.Dimension{Name: dimensionType}
+			for _, dim := range packet.DimensionCodec.Dimensions {
+				if dim.Name == dimensionType {
+					packet.Dimension = dim
+					break
+				}
+			}
+		} else if proto >= protocol.V1_16_2 {
 			_, dimensionTag, err := nbt.Read(buffer)
 			if err != nil {
 				return err
@@ -184,6 +208,14 @@ func (packet *PacketPlayOutJoinGame) Read(proto protocol.Protocol, buffer *bytes
 		packet.ViewDistance = viewDistance
 	}
 
+	if proto >= protocol.V1_18 {
+		simulationDistance, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.SimulationDistance = simulationDistance
+	}
+
 	reducedDebug, err := buffer.ReadBool()
 	if err != nil {
 		return err
@@ -212,10 +244,43 @@ func (packet *PacketPlayOutJoinGame) Read(proto protocol.Protocol, buffer *bytes
 		packet.IsFlat = isFlat
 	}
 
+	if proto >= protocol.V1_19 {
+		hasDeathLocation, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if hasDeathLocation {
+			deathDimension, err := buffer.ReadUtf(32767)
+			if err != nil {
+				return err
+			}
+			packet.DeathDimension = deathDimension
+
+			deathPosition, err := buffer.ReadInt64()
+			if err != nil {
+				return err
+			}
+			packet.DeathPosition = deathPosition
+		}
+	}
+
+	if proto >= protocol.V1_20 {
+		portalCooldown, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.PortalCooldown = portalCooldown
+	}
+
 	return nil
 }
 
 func (packet *PacketPlayOutJoinGame) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_20_2 {
+		return packet.writeWithoutCodec(buffer)
+	}
+
 	if err := buffer.WriteInt32(packet.EntityID); err != nil {
 		return err
 	}
@@ -249,7 +314,11 @@ func (packet *PacketPlayOutJoinGame) Write(proto protocol.Protocol, buffer *byte
 			return err
 		}
 
-		if proto >= protocol.V1_16_2 {
+		if proto >= protocol.V1_19 {
+			if err := buffer.WriteUtf(packet.Dimension.Name, 32767); err != nil {
+				return err
+			}
+		} else if proto >= protocol.V1_16_2 {
 			if err := nbt.Write(buffer, "", packet.Dimension.ToCompound(proto)); err != nil {
 				return err
 			}
@@ -306,6 +375,12 @@ func (packet *PacketPlayOutJoinGame) Write(proto protocol.Protocol, buffer *byte
 		}
 	}
 
+	if proto >= protocol.V1_18 {
+		if err := buffer.WriteVarInt(packet.SimulationDistance); err != nil {
+			return err
+		}
+	}
+
 	if err := buffer.WriteBool(packet.ReducedDebug); err != nil {
 		return err
 	}
@@ -326,5 +401,254 @@ func (packet *PacketPlayOutJoinGame) Write(proto protocol.Protocol, buffer *byte
 		}
 	}
 
+	if proto >= protocol.V1_19 {
+		if err := buffer.WriteBool(packet.DeathDimension != ""); err != nil {
+			return err
+		}
+
+		if packet.DeathDimension != "" {
+			if err := buffer.WriteUtf(packet.DeathDimension, 32767); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteInt64(packet.DeathPosition); err != nil {
+				return err
+			}
+		}
+	}
+
+	if proto >= protocol.V1_20 {
+		if err := buffer.WriteVarInt(packet.PortalCooldown); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readWithoutCodec reads the layout used from 1.20.2, where the codec is sent during configuration
+func (packet *PacketPlayOutJoinGame) readWithoutCodec(buffer *bytes.Buffer) error {
+	entityID, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.EntityID = entityID
+
+	hardcore, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.Hardcore = hardcore
+
+	worldCount, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+
+	var worldNames []string
+	for i := worldCount; i > 0; i-- {
+		worldName, err := buffer.ReadUtf(32767)
+		if err != nil {
+			return err
+		}
+		worldNames = append(worldNames, worldName)
+	}
+	packet.WorldNames = worldNames
+
+	maxPlayers, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.MaxPlayers = maxPlayers
+
+	viewDistance, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.ViewDistance = viewDistance
+
+	simulationDistance, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.SimulationDistance = simulationDistance
+
+	reducedDebug, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.ReducedDebug = reducedDebug
+
+	respawnScreen, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.RespawnScreen = respawnScreen
+
+	doLimitedCrafting, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.DoLimitedCrafting = doLimitedCrafting
+
+	dimensionType, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.Dimension = protocol.Dimension{Name: dimensionType}
+
+	worldName, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+	packet.WorldName = worldName
+
+	hashedSeed, err := buffer.ReadInt64()
+	if err != nil {
+		return err
+	}
+	packet.HashedSeed = hashedSeed
+
+	gamemode, err := buffer.ReadUint8()
+	if err != nil {
+		return err
+	}
+	packet.Gamemode = gamemode
+
+	previousGamemode, err := buffer.ReadInt8()
+	if err != nil {
+		return err
+	}
+	packet.PreviousGamemode = previousGamemode
+
+	isDebug, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.IsDebug = isDebug
+
+	isFlat, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.IsFlat = isFlat
+
+	hasDeathLocation, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+
+	if hasDeathLocation {
+		deathDimension, err := buffer.ReadUtf(32767)
+		if err != nil {
+			return err
+		}
+		packet.DeathDimension = deathDimension
+
+		deathPosition, err := buffer.ReadInt64()
+		if err != nil {
+			return err
+		}
+		packet.DeathPosition = deathPosition
+	}
+
+	portalCooldown, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+	packet.PortalCooldown = portalCooldown
+
+	return nil
+}
+
+func (packet *PacketPlayOutJoinGame) writeWithoutCodec(buffer *bytes.Buffer) error {
+	if err := buffer.WriteInt32(packet.EntityID); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.Hardcore); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteVarInt(int32(len(packet.WorldNames))); err != nil {
+		return err
+	}
+
+	for _, worldName := range packet.WorldNames {
+		if err := buffer.WriteUtf(worldName, 32767); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteVarInt(packet.MaxPlayers); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteVarInt(packet.ViewDistance); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteVarInt(packet.SimulationDistance); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.ReducedDebug); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.RespawnScreen); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.DoLimitedCrafting); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(packet.Dimension.Name, 32767); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(packet.WorldName, 32767); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt64(packet.HashedSeed); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUint8(packet.Gamemode); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt8(packet.PreviousGamemode); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.IsDebug); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.IsFlat); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteBool(packet.DeathDimension != ""); err != nil {
+		return err
+	}
+
+	if packet.DeathDimension != "" {
+		if err := buffer.WriteUtf(packet.DeathDimension, 32767); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteInt64(packet.DeathPosition); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteVarInt(packet.PortalCooldown); err != nil {
+		return err
+	}
+
 	return nil
 }
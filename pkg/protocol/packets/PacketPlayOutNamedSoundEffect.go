@@ -0,0 +1,185 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/sounds"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayOutNamedSoundEffect struct {
+	Sound    string
+	Category sounds.Category
+	X, Y, Z  float64
+	Volume   float32
+	Pitch    float32
+	// Seed picks the sound variant on 1.19+, and FixedRange overrides the volume based range on 1.19.3+ when set
+	Seed       int64
+	FixedRange float32
+}
+
+func (packet *PacketPlayOutNamedSoundEffect) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutNamedSoundEffect) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_19_3 {
+		// Sounds are registry references from 1.19.3, where 0 means the sound is sent inline
+		soundID, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+
+		if soundID != 0 {
+			return errors.New("sound registry references are not supported")
+		}
+	}
+
+	sound, err := buffer.ReadUtf(256)
+	if err != nil {
+		return err
+	}
+	packet.Sound = sound
+
+	if proto >= protocol.V1_19_3 {
+		hasFixedRange, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if hasFixedRange {
+			fixedRange, err := buffer.ReadFloat32()
+			if err != nil {
+				return err
+			}
+			packet.FixedRange = fixedRange
+		}
+	}
+
+	if proto >= protocol.V1_9 {
+		category, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.Category = sounds.Category(category)
+	}
+
+	// Positions are sent as fixed-point numbers with 3 fraction bits
+	x, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.X = float64(x) / 8
+
+	y, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.Y = float64(y) / 8
+
+	z, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.Z = float64(z) / 8
+
+	volume, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.Volume = volume
+
+	if proto >= protocol.V1_10 {
+		pitch, err := buffer.ReadFloat32()
+		if err != nil {
+			return err
+		}
+		packet.Pitch = pitch
+	} else {
+		pitch, err := buffer.ReadUint8()
+		if err != nil {
+			return err
+		}
+		packet.Pitch = float32(pitch) / 63
+	}
+
+	if proto >= protocol.V1_19 {
+		seed, err := buffer.ReadInt64()
+		if err != nil {
+			return err
+		}
+		packet.Seed = seed
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutNamedSoundEffect) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_19_3 {
+		if err := buffer.WriteVarInt(0); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteUtf(packet.Sound, 256); err != nil {
+		return err
+	}
+
+	if proto >= protocol.V1_19_3 {
+		if err := buffer.WriteBool(packet.FixedRange != 0); err != nil {
+			return err
+		}
+
+		if packet.FixedRange != 0 {
+			if err := buffer.WriteFloat32(packet.FixedRange); err != nil {
+				return err
+			}
+		}
+	}
+
+	if proto >= protocol.V1_9 {
+		if err := buffer.WriteVarInt(int32(packet.Category)); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteInt32(int32(packet.X * 8)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt32(int32(packet.Y * 8)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt32(int32(packet.Z * 8)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.Volume); err != nil {
+		return err
+	}
+
+	if proto >= protocol.V1_10 {
+		if err := buffer.WriteFloat32(packet.Pitch); err != nil {
+			return err
+		}
+	} else {
+		pitch := packet.Pitch * 63
+		if pitch > 255 {
+			pitch = 255
+		} else if pitch < 0 {
+			pitch = 0
+		}
+		if err := buffer.WriteUint8(uint8(pitch)); err != nil {
+			return err
+		}
+	}
+
+	if proto >= protocol.V1_19 {
+		if err := buffer.WriteInt64(packet.Seed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
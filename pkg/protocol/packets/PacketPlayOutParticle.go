@@ -0,0 +1,189 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayOutParticle struct {
+	ParticleID                int32
+	LongDistance              bool
+	X, Y, Z                   float64
+	OffsetX, OffsetY, OffsetZ float32
+	ParticleData              float32
+	Count                     int32
+	// Data holds the particle specific fields, already encoded for the target protocol
+	Data []byte
+}
+
+func (packet *PacketPlayOutParticle) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutParticle) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_19 {
+		particleID, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.ParticleID = particleID
+	} else {
+		particleID, err := buffer.ReadInt32()
+		if err != nil {
+			return err
+		}
+		packet.ParticleID = particleID
+	}
+
+	longDistance, err := buffer.ReadBool()
+	if err != nil {
+		return err
+	}
+	packet.LongDistance = longDistance
+
+	if proto >= protocol.V1_15 {
+		x, err := buffer.ReadFloat64()
+		if err != nil {
+			return err
+		}
+		packet.X = x
+
+		y, err := buffer.ReadFloat64()
+		if err != nil {
+			return err
+		}
+		packet.Y = y
+
+		z, err := buffer.ReadFloat64()
+		if err != nil {
+			return err
+		}
+		packet.Z = z
+	} else {
+		x, err := buffer.ReadFloat32()
+		if err != nil {
+			return err
+		}
+		packet.X = float64(x)
+
+		y, err := buffer.ReadFloat32()
+		if err != nil {
+			return err
+		}
+		packet.Y = float64(y)
+
+		z, err := buffer.ReadFloat32()
+		if err != nil {
+			return err
+		}
+		packet.Z = float64(z)
+	}
+
+	offsetX, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.OffsetX = offsetX
+
+	offsetY, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.OffsetY = offsetY
+
+	offsetZ, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.OffsetZ = offsetZ
+
+	particleData, err := buffer.ReadFloat32()
+	if err != nil {
+		return err
+	}
+	packet.ParticleData = particleData
+
+	count, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.Count = count
+
+	// The data length depends on the particle, so we just keep whatever is left
+	var data = make([]byte, buffer.Len())
+	_, err = buffer.Read(data)
+	if err != nil {
+		return err
+	}
+	packet.Data = data
+
+	return nil
+}
+
+func (packet *PacketPlayOutParticle) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_19 {
+		if err := buffer.WriteVarInt(packet.ParticleID); err != nil {
+			return err
+		}
+	} else {
+		if err := buffer.WriteInt32(packet.ParticleID); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteBool(packet.LongDistance); err != nil {
+		return err
+	}
+
+	if proto >= protocol.V1_15 {
+		if err := buffer.WriteFloat64(packet.X); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteFloat64(packet.Y); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteFloat64(packet.Z); err != nil {
+			return err
+		}
+	} else {
+		if err := buffer.WriteFloat32(float32(packet.X)); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteFloat32(float32(packet.Y)); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteFloat32(float32(packet.Z)); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteFloat32(packet.OffsetX); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.OffsetY); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.OffsetZ); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteFloat32(packet.ParticleData); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt32(packet.Count); err != nil {
+		return err
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package packets
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketPlayOutPluginMessage uses namespaced channel names, they are
+// translated to their legacy names for clients older than 1.13
+type PacketPlayOutPluginMessage struct {
+	Channel string
+	Data    []byte
+}
+
+const (
+	BrandChannel      = "minecraft:brand"
+	RegisterChannel   = "minecraft:register"
+	UnregisterChannel = "minecraft:unregister"
+)
+
+// legacyChannels maps the channels that were renamed in 1.13 to their old names
+var legacyChannels = map[string]string{
+	BrandChannel:      "MC|Brand",
+	RegisterChannel:   "REGISTER",
+	UnregisterChannel: "UNREGISTER",
+	"bungeecord:main": "BungeeCord",
+}
+
+func (packet *PacketPlayOutPluginMessage) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutPluginMessage) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	channel, err := buffer.ReadUtf(maxChannelLength(proto))
+	if err != nil {
+		return err
+	}
+	packet.Channel = FromLegacyChannel(channel, proto)
+
+	if buffer.Len() > maxPluginMessageLength {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+	packet.Data = append([]byte(nil), buffer.Next(buffer.Len())...)
+
+	return nil
+}
+
+func (packet *PacketPlayOutPluginMessage) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(ToLegacyChannel(packet.Channel, proto), maxChannelLength(proto)); err != nil {
+		return err
+	}
+
+	if len(packet.Data) > maxPluginMessageLength {
+		return errors.New("plugin message data is longer than maximum allowed")
+	}
+
+	if _, err := buffer.Write(packet.Data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ToLegacyChannel returns the name the given protocol uses for the channel,
+// channels without a legacy name keep the namespaced one
+func ToLegacyChannel(channel string, proto protocol.Protocol) string {
+	if proto < protocol.V1_13 {
+		if legacy, ok := legacyChannels[channel]; ok {
+			return legacy
+		}
+	}
+	return channel
+}
+
+// FromLegacyChannel returns the namespaced name of a channel sent by the given protocol
+func FromLegacyChannel(channel string, proto protocol.Protocol) string {
+	if proto < protocol.V1_13 {
+		for namespaced, legacy := range legacyChannels {
+			if legacy == channel {
+				return namespaced
+			}
+		}
+	}
+	return channel
+}
+
+// maxChannelLength is how long channel names can be, legacy names had a much lower limit
+func maxChannelLength(proto protocol.Protocol) int {
+	if proto < protocol.V1_13 {
+		return 20
+	}
+	return 32767
+}
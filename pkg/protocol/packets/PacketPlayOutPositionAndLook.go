@@ -10,6 +10,8 @@ type PacketPlayOutPositionAndLook struct {
 	Yaw, Pitch float32
 	Flags      uint8
 	TeleportID int32
+	// DismountVehicle is only sent from 1.17 to 1.19.3
+	DismountVehicle bool
 }
 
 func (packet *PacketPlayOutPositionAndLook) GetID(proto protocol.Protocol) (int32, error) {
@@ -61,6 +63,14 @@ func (packet *PacketPlayOutPositionAndLook) Read(proto protocol.Protocol, buffer
 		packet.TeleportID = teleportID
 	}
 
+	if proto >= protocol.V1_17 && proto < protocol.V1_19_4 {
+		dismountVehicle, err := buffer.ReadBool()
+		if err != nil {
+			return err
+		}
+		packet.DismountVehicle = dismountVehicle
+	}
+
 	return nil
 }
 
@@ -95,5 +105,11 @@ func (packet *PacketPlayOutPositionAndLook) Write(proto protocol.Protocol, buffe
 		}
 	}
 
+	if proto >= protocol.V1_17 && proto < protocol.V1_19_4 {
+		if err := buffer.WriteBool(packet.DismountVehicle); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,140 @@
+package packets
+
+import (
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type (
+	PacketPlayOutScoreboardObjective struct {
+		Name        string
+		Mode        ObjectiveMode
+		DisplayName []chat.Component
+		RenderType  ObjectiveRenderType
+	}
+
+	ObjectiveMode       int8
+	ObjectiveRenderType int32
+)
+
+const (
+	CreateObjectiveMode ObjectiveMode = iota
+	RemoveObjectiveMode
+	UpdateObjectiveMode
+)
+
+const (
+	IntegerObjectiveRenderType ObjectiveRenderType = iota
+	HeartsObjectiveRenderType
+)
+
+func (renderType ObjectiveRenderType) String() string {
+	switch renderType {
+	case IntegerObjectiveRenderType:
+		return "integer"
+	case HeartsObjectiveRenderType:
+		return "hearts"
+	default:
+		return "unknown"
+	}
+}
+
+func (packet *PacketPlayOutScoreboardObjective) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutScoreboardObjective) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	name, err := buffer.ReadUtf(16)
+	if err != nil {
+		return err
+	}
+	packet.Name = name
+
+	mode, err := buffer.ReadInt8()
+	if err != nil {
+		return err
+	}
+	packet.Mode = ObjectiveMode(mode)
+
+	if packet.Mode == CreateObjectiveMode || packet.Mode == UpdateObjectiveMode {
+		if proto >= protocol.V1_13 {
+			displayNameStr, err := buffer.ReadUtf(32767)
+			if err != nil {
+				return err
+			}
+
+			displayName, err := chat.FromJSON([]byte(displayNameStr))
+			if err != nil {
+				return err
+			}
+			packet.DisplayName = displayName
+
+			renderType, err := buffer.ReadVarInt()
+			if err != nil {
+				return err
+			}
+			packet.RenderType = ObjectiveRenderType(renderType)
+		} else {
+			displayName, err := buffer.ReadUtf(32)
+			if err != nil {
+				return err
+			}
+			packet.DisplayName = []chat.Component{&chat.TextComponent{Text: displayName}}
+
+			renderType, err := buffer.ReadUtf(16)
+			if err != nil {
+				return err
+			}
+
+			switch renderType {
+			case IntegerObjectiveRenderType.String():
+				packet.RenderType = IntegerObjectiveRenderType
+			case HeartsObjectiveRenderType.String():
+				packet.RenderType = HeartsObjectiveRenderType
+			default:
+				return fmt.Errorf("received invalid objective render type %s", renderType)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutScoreboardObjective) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.Name, 16); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt8(int8(packet.Mode)); err != nil {
+		return err
+	}
+
+	if packet.Mode == CreateObjectiveMode || packet.Mode == UpdateObjectiveMode {
+		if proto >= protocol.V1_13 {
+			displayName, err := chat.ToJSON(packet.DisplayName)
+			if err != nil {
+				return err
+			}
+
+			if err := buffer.WriteUtf(string(displayName), 32767); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteVarInt(int32(packet.RenderType)); err != nil {
+				return err
+			}
+		} else {
+			if err := buffer.WriteUtf(chat.ToLegacyText(packet.DisplayName), 32); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteUtf(packet.RenderType.String(), 16); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+// PacketPlayOutStartConfiguration sends the client back into configuration, it's only known by 1.20.2+ clients
+type PacketPlayOutStartConfiguration struct{}
+
+func (packet *PacketPlayOutStartConfiguration) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutStartConfiguration) Read(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
+
+func (packet *PacketPlayOutStartConfiguration) Write(_ protocol.Protocol, _ *bytes.Buffer) error {
+	return nil
+}
@@ -0,0 +1,123 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type (
+	PacketPlayOutTabComplete struct {
+		TransactionID int32
+		Start         int32
+		Length        int32
+		Matches       []TabCompleteMatch
+	}
+
+	TabCompleteMatch struct {
+		Match   string
+		Tooltip []chat.Component
+	}
+)
+
+func (packet *PacketPlayOutTabComplete) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutTabComplete) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_13 {
+		transactionID, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.TransactionID = transactionID
+
+		start, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.Start = start
+
+		length, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.Length = length
+	}
+
+	count, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+
+	var matches []TabCompleteMatch
+	for i := count; i > 0; i-- {
+		var match TabCompleteMatch
+
+		text, err := buffer.ReadUtf(32767)
+		if err != nil {
+			return err
+		}
+		match.Match = text
+
+		if proto >= protocol.V1_13 {
+			hasTooltip, err := buffer.ReadBool()
+			if err != nil {
+				return err
+			}
+
+			if hasTooltip {
+				tooltip, err := readComponent(buffer)
+				if err != nil {
+					return err
+				}
+				match.Tooltip = tooltip
+			}
+		}
+
+		matches = append(matches, match)
+	}
+	packet.Matches = matches
+
+	return nil
+}
+
+func (packet *PacketPlayOutTabComplete) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if proto >= protocol.V1_13 {
+		if err := buffer.WriteVarInt(packet.TransactionID); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteVarInt(packet.Start); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteVarInt(packet.Length); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.WriteVarInt(int32(len(packet.Matches))); err != nil {
+		return err
+	}
+
+	for _, match := range packet.Matches {
+		if err := buffer.WriteUtf(match.Match, 32767); err != nil {
+			return err
+		}
+
+		if proto >= protocol.V1_13 {
+			if err := buffer.WriteBool(match.Tooltip != nil); err != nil {
+				return err
+			}
+
+			if match.Tooltip != nil {
+				if err := writeComponent(buffer, match.Tooltip); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
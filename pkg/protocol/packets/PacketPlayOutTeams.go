@@ -0,0 +1,306 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type (
+	PacketPlayOutTeams struct {
+		Name              string
+		Mode              TeamMode
+		DisplayName       []chat.Component
+		Prefix            []chat.Component
+		Suffix            []chat.Component
+		FriendlyFlags     TeamFriendlyFlags
+		NameTagVisibility NameTagVisibility
+		CollisionRule     CollisionRule
+		Color             *chat.Color
+		Entities          []string
+	}
+
+	TeamMode          int8
+	TeamFriendlyFlags uint8
+	NameTagVisibility string
+	CollisionRule     string
+)
+
+const (
+	CreateTeamMode TeamMode = iota
+	RemoveTeamMode
+	UpdateTeamMode
+	AddEntitiesTeamMode
+	RemoveEntitiesTeamMode
+)
+
+const (
+	FriendlyFireTeamFlag TeamFriendlyFlags = 1 << iota
+	SeeInvisibleTeamFlag
+)
+
+const (
+	AlwaysNameTagVisibility            NameTagVisibility = "always"
+	HideForOtherTeamsNameTagVisibility NameTagVisibility = "hideForOtherTeams"
+	HideForOwnTeamNameTagVisibility    NameTagVisibility = "hideForOwnTeam"
+	NeverNameTagVisibility             NameTagVisibility = "never"
+
+	AlwaysCollisionRule         CollisionRule = "always"
+	PushOtherTeamsCollisionRule CollisionRule = "pushOtherTeams"
+	PushOwnTeamCollisionRule    CollisionRule = "pushOwnTeam"
+	NeverCollisionRule          CollisionRule = "never"
+)
+
+// Team colors are sent as the index of the legacy formatting code,
+// where reset is used for teams without a color.
+var teamColors = []chat.Color{
+	chat.Black, chat.DarkBlue, chat.DarkGreen, chat.DarkAqua, chat.DarkRed, chat.DarkPurple, chat.Gold, chat.Gray,
+	chat.DarkGray, chat.Blue, chat.Green, chat.Aqua, chat.Red, chat.LightPurple, chat.Yellow, chat.White,
+	chat.Obfuscated, chat.Bold, chat.Strikethrough, chat.Underline, chat.Italic, chat.Reset,
+}
+
+func (packet *PacketPlayOutTeams) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutTeams) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	name, err := buffer.ReadUtf(16)
+	if err != nil {
+		return err
+	}
+	packet.Name = name
+
+	mode, err := buffer.ReadInt8()
+	if err != nil {
+		return err
+	}
+	packet.Mode = TeamMode(mode)
+
+	if packet.Mode == CreateTeamMode || packet.Mode == UpdateTeamMode {
+		if proto >= protocol.V1_13 {
+			displayName, err := readComponent(buffer)
+			if err != nil {
+				return err
+			}
+			packet.DisplayName = displayName
+		} else {
+			displayName, err := buffer.ReadUtf(32)
+			if err != nil {
+				return err
+			}
+			packet.DisplayName = []chat.Component{&chat.TextComponent{Text: displayName}}
+
+			prefix, err := buffer.ReadUtf(16)
+			if err != nil {
+				return err
+			}
+			packet.Prefix = []chat.Component{&chat.TextComponent{Text: prefix}}
+
+			suffix, err := buffer.ReadUtf(16)
+			if err != nil {
+				return err
+			}
+			packet.Suffix = []chat.Component{&chat.TextComponent{Text: suffix}}
+		}
+
+		friendlyFlags, err := buffer.ReadUint8()
+		if err != nil {
+			return err
+		}
+		packet.FriendlyFlags = TeamFriendlyFlags(friendlyFlags)
+
+		nameTagVisibility, err := buffer.ReadUtf(32)
+		if err != nil {
+			return err
+		}
+		packet.NameTagVisibility = NameTagVisibility(nameTagVisibility)
+
+		if proto >= protocol.V1_9 {
+			collisionRule, err := buffer.ReadUtf(32)
+			if err != nil {
+				return err
+			}
+			packet.CollisionRule = CollisionRule(collisionRule)
+		}
+
+		if proto >= protocol.V1_13 {
+			color, err := buffer.ReadVarInt()
+			if err != nil {
+				return err
+			}
+			packet.Color = teamColorFromID(color)
+
+			prefix, err := readComponent(buffer)
+			if err != nil {
+				return err
+			}
+			packet.Prefix = prefix
+
+			suffix, err := readComponent(buffer)
+			if err != nil {
+				return err
+			}
+			packet.Suffix = suffix
+		} else {
+			color, err := buffer.ReadInt8()
+			if err != nil {
+				return err
+			}
+			packet.Color = teamColorFromID(int32(color))
+		}
+	}
+
+	if packet.Mode == CreateTeamMode || packet.Mode == AddEntitiesTeamMode || packet.Mode == RemoveEntitiesTeamMode {
+		entityCount, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+
+		var entities []string
+		for i := entityCount; i > 0; i-- {
+			entity, err := buffer.ReadUtf(40)
+			if err != nil {
+				return err
+			}
+			entities = append(entities, entity)
+		}
+		packet.Entities = entities
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutTeams) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.Name, 16); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt8(int8(packet.Mode)); err != nil {
+		return err
+	}
+
+	if packet.Mode == CreateTeamMode || packet.Mode == UpdateTeamMode {
+		if proto >= protocol.V1_13 {
+			if err := writeComponent(buffer, packet.DisplayName); err != nil {
+				return err
+			}
+		} else {
+			if err := buffer.WriteUtf(chat.ToLegacyText(packet.DisplayName), 32); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteUtf(chat.ToLegacyText(packet.Prefix), 16); err != nil {
+				return err
+			}
+
+			if err := buffer.WriteUtf(chat.ToLegacyText(packet.Suffix), 16); err != nil {
+				return err
+			}
+		}
+
+		if err := buffer.WriteUint8(uint8(packet.FriendlyFlags)); err != nil {
+			return err
+		}
+
+		nameTagVisibility := packet.NameTagVisibility
+		if nameTagVisibility == "" {
+			nameTagVisibility = AlwaysNameTagVisibility
+		}
+		if err := buffer.WriteUtf(string(nameTagVisibility), 32); err != nil {
+			return err
+		}
+
+		if proto >= protocol.V1_9 {
+			collisionRule := packet.CollisionRule
+			if collisionRule == "" {
+				collisionRule = AlwaysCollisionRule
+			}
+			if err := buffer.WriteUtf(string(collisionRule), 32); err != nil {
+				return err
+			}
+		}
+
+		if proto >= protocol.V1_13 {
+			if err := buffer.WriteVarInt(teamColorToID(packet.Color)); err != nil {
+				return err
+			}
+
+			if err := writeComponent(buffer, packet.Prefix); err != nil {
+				return err
+			}
+
+			if err := writeComponent(buffer, packet.Suffix); err != nil {
+				return err
+			}
+		} else {
+			color := teamColorToID(packet.Color)
+			if packet.Color == nil {
+				color = -1
+			}
+			if err := buffer.WriteInt8(int8(color)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if packet.Mode == CreateTeamMode || packet.Mode == AddEntitiesTeamMode || packet.Mode == RemoveEntitiesTeamMode {
+		if err := buffer.WriteVarInt(int32(len(packet.Entities))); err != nil {
+			return err
+		}
+
+		for _, entity := range packet.Entities {
+			if err := buffer.WriteUtf(entity, 40); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func teamColorToID(color *chat.Color) int32 {
+	if color == nil {
+		return int32(len(teamColors) - 1)
+	}
+
+	code := color.Code
+	if code == "" {
+		code = chat.FindNearest(*color).Code
+	}
+
+	for id, teamColor := range teamColors {
+		if teamColor.Code == code {
+			return int32(id)
+		}
+	}
+	return int32(len(teamColors) - 1)
+}
+
+func teamColorFromID(id int32) *chat.Color {
+	if id < 0 || id >= int32(len(teamColors)-1) {
+		return nil
+	}
+
+	color := teamColors[id]
+	return &color
+}
+
+func readComponent(buffer *bytes.Buffer) ([]chat.Component, error) {
+	str, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return nil, err
+	}
+	return chat.FromJSON([]byte(str))
+}
+
+func writeComponent(buffer *bytes.Buffer, component []chat.Component) error {
+	if len(component) == 0 {
+		component = []chat.Component{&chat.TextComponent{}}
+	}
+
+	data, err := chat.ToJSON(component)
+	if err != nil {
+		return err
+	}
+	return buffer.WriteUtf(string(data), 32767)
+}
@@ -0,0 +1,142 @@
+package packets
+
+import (
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type (
+	PacketPlayOutTitle struct {
+		Action  TitleAction
+		Text    []chat.Component
+		FadeIn  int32
+		Stay    int32
+		FadeOut int32
+	}
+
+	TitleAction int32
+)
+
+const (
+	SetTitleAction TitleAction = iota
+	SetSubtitleAction
+	SetActionBarAction
+	SetTimesAction
+	HideTitleAction
+	ResetTitleAction
+)
+
+func (packet *PacketPlayOutTitle) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutTitle) Read(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	actionID, err := buffer.ReadVarInt()
+	if err != nil {
+		return err
+	}
+
+	action, err := titleActionFromID(proto, actionID)
+	if err != nil {
+		return err
+	}
+	packet.Action = action
+
+	switch packet.Action {
+	case SetTitleAction, SetSubtitleAction, SetActionBarAction:
+		textStr, err := buffer.ReadUtf(32767)
+		if err != nil {
+			return err
+		}
+
+		text, err := chat.FromJSON([]byte(textStr))
+		if err != nil {
+			return err
+		}
+		packet.Text = text
+	case SetTimesAction:
+		fadeIn, err := buffer.ReadInt32()
+		if err != nil {
+			return err
+		}
+		packet.FadeIn = fadeIn
+
+		stay, err := buffer.ReadInt32()
+		if err != nil {
+			return err
+		}
+		packet.Stay = stay
+
+		fadeOut, err := buffer.ReadInt32()
+		if err != nil {
+			return err
+		}
+		packet.FadeOut = fadeOut
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutTitle) Write(proto protocol.Protocol, buffer *bytes.Buffer) error {
+	actionID, err := titleActionToID(proto, packet.Action)
+	if err != nil {
+		return err
+	}
+
+	if err := buffer.WriteVarInt(actionID); err != nil {
+		return err
+	}
+
+	switch packet.Action {
+	case SetTitleAction, SetSubtitleAction, SetActionBarAction:
+		text, err := chat.ToJSON(packet.Text)
+		if err != nil {
+			return err
+		}
+
+		if err := buffer.WriteUtf(string(text), 32767); err != nil {
+			return err
+		}
+	case SetTimesAction:
+		if err := buffer.WriteInt32(packet.FadeIn); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteInt32(packet.Stay); err != nil {
+			return err
+		}
+
+		if err := buffer.WriteInt32(packet.FadeOut); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Before 1.11 the action bar wasn't part of the title packet,
+// so every action after it was shifted down by one.
+func titleActionToID(proto protocol.Protocol, action TitleAction) (int32, error) {
+	if proto < protocol.V1_11 {
+		switch action {
+		case SetActionBarAction:
+			return 0, fmt.Errorf("title action %d is not supported by protocol %d", action, proto)
+		case SetTimesAction, HideTitleAction, ResetTitleAction:
+			return int32(action) - 1, nil
+		}
+	}
+	return int32(action), nil
+}
+
+func titleActionFromID(proto protocol.Protocol, id int32) (TitleAction, error) {
+	if proto < protocol.V1_11 && id >= int32(SetActionBarAction) {
+		id++
+	}
+
+	if id < int32(SetTitleAction) || id > int32(ResetTitleAction) {
+		return 0, fmt.Errorf("received invalid title action %d", id)
+	}
+	return TitleAction(id), nil
+}
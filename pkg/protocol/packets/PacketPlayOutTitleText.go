@@ -0,0 +1,43 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+type PacketPlayOutTitleText struct {
+	Text []chat.Component
+}
+
+func (packet *PacketPlayOutTitleText) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutTitleText) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	textStr, err := buffer.ReadUtf(32767)
+	if err != nil {
+		return err
+	}
+
+	text, err := chat.FromJSON([]byte(textStr))
+	if err != nil {
+		return err
+	}
+	packet.Text = text
+
+	return nil
+}
+
+func (packet *PacketPlayOutTitleText) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	text, err := chat.ToJSON(packet.Text)
+	if err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(string(text), 32767); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type PacketPlayOutTitleTimes struct {
+	FadeIn  int32
+	Stay    int32
+	FadeOut int32
+}
+
+func (packet *PacketPlayOutTitleTimes) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutTitleTimes) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	fadeIn, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.FadeIn = fadeIn
+
+	stay, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.Stay = stay
+
+	fadeOut, err := buffer.ReadInt32()
+	if err != nil {
+		return err
+	}
+	packet.FadeOut = fadeOut
+
+	return nil
+}
+
+func (packet *PacketPlayOutTitleTimes) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteInt32(packet.FadeIn); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt32(packet.Stay); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt32(packet.FadeOut); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+)
+
+type (
+	PacketPlayOutUpdateScore struct {
+		EntityName    string
+		Action        ScoreAction
+		ObjectiveName string
+		Value         int32
+	}
+
+	ScoreAction int8
+)
+
+const (
+	UpdateScoreAction ScoreAction = iota
+	RemoveScoreAction
+)
+
+func (packet *PacketPlayOutUpdateScore) GetID(proto protocol.Protocol) (int32, error) {
+	return GetID(proto, protocol.Play, protocol.ClientBound, packet)
+}
+
+func (packet *PacketPlayOutUpdateScore) Read(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	entityName, err := buffer.ReadUtf(40)
+	if err != nil {
+		return err
+	}
+	packet.EntityName = entityName
+
+	action, err := buffer.ReadInt8()
+	if err != nil {
+		return err
+	}
+	packet.Action = ScoreAction(action)
+
+	objectiveName, err := buffer.ReadUtf(16)
+	if err != nil {
+		return err
+	}
+	packet.ObjectiveName = objectiveName
+
+	if packet.Action != RemoveScoreAction {
+		value, err := buffer.ReadVarInt()
+		if err != nil {
+			return err
+		}
+		packet.Value = value
+	}
+
+	return nil
+}
+
+func (packet *PacketPlayOutUpdateScore) Write(_ protocol.Protocol, buffer *bytes.Buffer) error {
+	if err := buffer.WriteUtf(packet.EntityName, 40); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteInt8(int8(packet.Action)); err != nil {
+		return err
+	}
+
+	if err := buffer.WriteUtf(packet.ObjectiveName, 16); err != nil {
+		return err
+	}
+
+	if packet.Action != RemoveScoreAction {
+		if err := buffer.WriteVarInt(packet.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,344 @@
+// Command gen generates the packet registry from packets.json.
+//
+// Every packet lists its id for the protocol it was introduced or changed in,
+// which is then used by every later protocol until the next entry. A null id
+// removes the packet from that protocol onwards and entries under -1 also apply
+// to unknown protocols, like the ones sent by clients we don't support.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+)
+
+type (
+	spec map[string]map[string]map[string]map[string]*int32
+
+	entry struct {
+		name string
+		id   int32
+	}
+
+	registry map[protocol.Protocol]map[protocol.State]map[protocol.Direction][]entry
+)
+
+var (
+	states     = []protocol.State{protocol.Handshaking, protocol.Status, protocol.Login, protocol.Configuration, protocol.Play}
+	directions = []protocol.Direction{protocol.ClientBound, protocol.ServerBound}
+)
+
+func main() {
+	input := flag.String("input", "packets.json", "packet spec to read")
+	output := flag.String("output", "registry_gen.go", "go file to write")
+	protocolFile := flag.String("protocol", "../protocol.go", "go file declaring the protocol constants")
+	flag.Parse()
+
+	if err := run(*input, *output, *protocolFile); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output, protocolFile string) error {
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return err
+	}
+
+	var packets spec
+	if err := json.Unmarshal(data, &packets); err != nil {
+		return fmt.Errorf("%s: %w", input, err)
+	}
+
+	names, err := protocolNames(protocolFile)
+	if err != nil {
+		return err
+	}
+
+	reg, err := expand(packets)
+	if err != nil {
+		return fmt.Errorf("%s: %w", input, err)
+	}
+
+	source, err := generate(reg, names)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, source, 0644)
+}
+
+// protocolNames maps every protocol to the name of its constant, so the generated code stays readable
+func protocolNames(file string) (map[protocol.Protocol]string, error) {
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[protocol.Protocol]string)
+	for _, decl := range parsed.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			value := spec.(*ast.ValueSpec)
+			if typ, ok := value.Type.(*ast.Ident); !ok || typ.Name != "Protocol" || len(value.Values) != 1 {
+				continue
+			}
+
+			var literal string
+			switch expr := value.Values[0].(type) {
+			case *ast.BasicLit:
+				literal = expr.Value
+			case *ast.UnaryExpr:
+				if lit, ok := expr.X.(*ast.BasicLit); ok && expr.Op == token.SUB {
+					literal = "-" + lit.Value
+				}
+			}
+
+			number, err := strconv.ParseInt(literal, 0, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s: unsupported value for %s", file, value.Names[0].Name)
+			}
+			names[protocol.Protocol(number)] = value.Names[0].Name
+		}
+	}
+
+	for _, proto := range append([]protocol.Protocol{protocol.Unknown}, protocol.SupportedProtocols...) {
+		if _, ok := names[proto]; !ok {
+			return nil, fmt.Errorf("%s: no constant declared for protocol %d", file, proto)
+		}
+	}
+	return names, nil
+}
+
+// expand resolves the id every packet has in each supported protocol
+func expand(packets spec) (registry, error) {
+	protocols := append([]protocol.Protocol{protocol.Unknown}, protocol.SupportedProtocols...)
+	reg := make(registry)
+	for _, proto := range protocols {
+		reg[proto] = make(map[protocol.State]map[protocol.Direction][]entry)
+	}
+
+	for stateName, stateDirections := range packets {
+		state, ok := parseState(stateName)
+		if !ok {
+			return nil, fmt.Errorf("unknown state %q", stateName)
+		}
+
+		for directionName, directionPackets := range stateDirections {
+			direction, ok := parseDirection(directionName)
+			if !ok {
+				return nil, fmt.Errorf("unknown direction %q in %s", directionName, state)
+			}
+
+			for name, versions := range directionPackets {
+				changes, err := parseVersions(versions)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s %s: %w", state, direction, name, err)
+				}
+
+				for _, proto := range protocols {
+					id := resolve(changes, proto)
+					if id == nil {
+						continue
+					}
+
+					if reg[proto][state] == nil {
+						reg[proto][state] = make(map[protocol.Direction][]entry)
+					}
+					reg[proto][state][direction] = append(reg[proto][state][direction], entry{name: name, id: *id})
+				}
+			}
+		}
+	}
+
+	for _, proto := range protocols {
+		for state, stateDirections := range reg[proto] {
+			for direction, entries := range stateDirections {
+				sort.Slice(entries, func(i, j int) bool {
+					if entries[i].id == entries[j].id {
+						return entries[i].name < entries[j].name
+					}
+					return entries[i].id < entries[j].id
+				})
+
+				for i := 1; i < len(entries); i++ {
+					if entries[i].id == entries[i-1].id {
+						return nil, fmt.Errorf(
+							"%s and %s both use id 0x%02X for %s %s in protocol %d",
+							entries[i-1].name, entries[i].name, entries[i].id, state, direction, proto,
+						)
+					}
+				}
+			}
+		}
+	}
+	return reg, nil
+}
+
+type change struct {
+	proto protocol.Protocol
+	id    *int32
+}
+
+// parseVersions returns the changes a packet went through, sorted by protocol
+func parseVersions(versions map[string]*int32) ([]change, error) {
+	var changes []change
+	for version, id := range versions {
+		number, err := strconv.ParseInt(version, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protocol %q", version)
+		}
+
+		proto := protocol.Protocol(number)
+		if proto != protocol.Unknown && !protocol.IsSupported(proto) {
+			return nil, fmt.Errorf("unsupported protocol %d", proto)
+		}
+		if id != nil && *id < 0 {
+			return nil, fmt.Errorf("negative id for protocol %d", proto)
+		}
+		changes = append(changes, change{proto: proto, id: id})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].proto < changes[j].proto
+	})
+	return changes, nil
+}
+
+func resolve(changes []change, proto protocol.Protocol) *int32 {
+	var id *int32
+	for _, change := range changes {
+		if change.proto > proto {
+			break
+		}
+		id = change.id
+	}
+	return id
+}
+
+func parseState(name string) (protocol.State, bool) {
+	for _, state := range states {
+		if state.String() == name {
+			return state, true
+		}
+	}
+	return 0, false
+}
+
+func parseDirection(name string) (protocol.Direction, bool) {
+	for _, direction := range directions {
+		if direction.String() == name {
+			return direction, true
+		}
+	}
+	return 0, false
+}
+
+func generate(reg registry, names map[protocol.Protocol]string) ([]byte, error) {
+	protocols := append([]protocol.Protocol{protocol.Unknown}, protocol.SupportedProtocols...)
+
+	var packetNames []string
+	seen := make(map[string]bool)
+	for _, proto := range protocols {
+		for _, stateDirections := range reg[proto] {
+			for _, entries := range stateDirections {
+				for _, entry := range entries {
+					if !seen[entry.name] {
+						seen[entry.name] = true
+						packetNames = append(packetNames, entry.name)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(packetNames)
+
+	var buff bytes.Buffer
+	buff.WriteString("// Code generated by internal/gen from packets.json. DO NOT EDIT.\n\n")
+	buff.WriteString("package packets\n\n")
+	buff.WriteString("import \"github.com/r4g3baby/mcserver/pkg/protocol\"\n\n")
+
+	buff.WriteString("const (\n")
+	for i, name := range packetNames {
+		if i == 0 {
+			buff.WriteString(kindOf(name) + " kind = iota\n")
+		} else {
+			buff.WriteString(kindOf(name) + "\n")
+		}
+	}
+	buff.WriteString("kindCount\n)\n\n")
+
+	buff.WriteString("var (\n")
+	buff.WriteString("constructors = [kindCount]func() protocol.Packet{\n")
+	for _, name := range packetNames {
+		fmt.Fprintf(&buff, "%s: func() protocol.Packet { return &%s{} },\n", kindOf(name), name)
+	}
+	buff.WriteString("}\n\n")
+
+	buff.WriteString("kindNames = [kindCount]string{\n")
+	for _, name := range packetNames {
+		fmt.Fprintf(&buff, "%s: %q,\n", kindOf(name), name)
+	}
+	buff.WriteString("}\n\n")
+
+	buff.WriteString("packetIDs = map[protocol.Protocol]map[protocol.State]map[protocol.Direction]map[kind]int32{\n")
+	for _, proto := range protocols {
+		writeProtocol(&buff, reg[proto], names[proto])
+	}
+	buff.WriteString("}\n")
+	buff.WriteString(")\n\n")
+
+	buff.WriteString("func kindOfPacket(packet protocol.Packet) kind {\n")
+	buff.WriteString("switch packet.(type) {\n")
+	for _, name := range packetNames {
+		fmt.Fprintf(&buff, "case *%s:\nreturn %s\n", name, kindOf(name))
+	}
+	buff.WriteString("}\n")
+	buff.WriteString("return -1\n")
+	buff.WriteString("}\n")
+
+	return format.Source(buff.Bytes())
+}
+
+func writeProtocol(buff *bytes.Buffer, protoStates map[protocol.State]map[protocol.Direction][]entry, name string) {
+	buff.WriteString("protocol." + name + ": {\n")
+	for _, state := range states {
+		stateDirections, ok := protoStates[state]
+		if !ok {
+			continue
+		}
+
+		buff.WriteString("protocol." + state.String() + ": {\n")
+		for _, direction := range directions {
+			entries, ok := stateDirections[direction]
+			if !ok {
+				continue
+			}
+
+			buff.WriteString("protocol." + direction.String() + ": {\n")
+			for _, entry := range entries {
+				fmt.Fprintf(buff, "%s: 0x%02X,\n", kindOf(entry.name), entry.id)
+			}
+			buff.WriteString("},\n")
+		}
+		buff.WriteString("},\n")
+	}
+	buff.WriteString("},\n")
+}
+
+func kindOf(name string) string {
+	return "kind" + name
+}
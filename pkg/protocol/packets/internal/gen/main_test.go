@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryIsUpToDate(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "registry_gen.go")
+	if err := run("../../packets.json", output, "../../../protocol.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("../../registry_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(generated, current) {
+		t.Error("registry_gen.go is out of date with packets.json, run go generate")
+	}
+}
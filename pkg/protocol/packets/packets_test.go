@@ -0,0 +1,82 @@
+package packets
+
+import (
+	stdbytes "bytes"
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"testing"
+)
+
+type decoder struct {
+	proto protocol.Protocol
+	state protocol.State
+	id    int32
+}
+
+// serverBoundDecoders lists every packet a client can send, for every supported protocol
+func serverBoundDecoders() []decoder {
+	var decoders []decoder
+	for _, proto := range protocol.SupportedProtocols {
+		for _, state := range []protocol.State{protocol.Handshaking, protocol.Status, protocol.Login, protocol.Configuration, protocol.Play} {
+			for id := int32(0); id <= 0xFF; id++ {
+				if _, err := Get(proto, state, protocol.ServerBound, id); err == nil {
+					decoders = append(decoders, decoder{proto, state, id})
+				}
+			}
+		}
+	}
+	return decoders
+}
+
+// reencode reads the input into a new packet and writes it back
+func reencode(decoder decoder, input []byte) ([]byte, error) {
+	packet, err := Get(decoder.proto, decoder.state, protocol.ServerBound, decoder.id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := packet.Read(decoder.proto, bytes.NewBuffer(input)); err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := packet.Write(decoder.proto, buffer); err != nil {
+		return nil, fmt.Errorf("failed to write %T: %w", packet, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func FuzzServerBoundPackets(f *testing.F) {
+	decoders := serverBoundDecoders()
+	if len(decoders) == 0 {
+		f.Fatal("no serverbound packets are registered")
+	}
+
+	for _, decoder := range decoders {
+		packet, _ := Get(decoder.proto, decoder.state, protocol.ServerBound, decoder.id)
+		buffer := bytes.NewBuffer(nil)
+		if err := packet.Write(decoder.proto, buffer); err != nil {
+			f.Fatalf("failed to write %T: %v", packet, err)
+		}
+		f.Add(buffer.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, decoder := range decoders {
+			encoded, err := reencode(decoder, input)
+			if err != nil {
+				continue
+			}
+
+			// Writing what was read and reading it again must give back the same packet
+			again, err := reencode(decoder, encoded)
+			if err != nil {
+				t.Fatalf("%+v: failed to read a written packet: %v", decoder, err)
+			}
+			if !stdbytes.Equal(encoded, again) {
+				t.Fatalf("%+v: packet changed after being written, %x != %x", decoder, encoded, again)
+			}
+		}
+	})
+}
@@ -0,0 +1,2645 @@
+// Code generated by internal/gen from packets.json. DO NOT EDIT.
+
+package packets
+
+import "github.com/r4g3baby/mcserver/pkg/protocol"
+
+const (
+	kindPacketConfigurationInFinish kind = iota
+	kindPacketConfigurationInKeepAlive
+	kindPacketConfigurationInPluginMessage
+	kindPacketConfigurationInResourcePack
+	kindPacketConfigurationOutDisconnect
+	kindPacketConfigurationOutFeatureFlags
+	kindPacketConfigurationOutFinish
+	kindPacketConfigurationOutKeepAlive
+	kindPacketConfigurationOutPluginMessage
+	kindPacketConfigurationOutRegistryData
+	kindPacketConfigurationOutResourcePack
+	kindPacketHandshakingStart
+	kindPacketLoginInAcknowledged
+	kindPacketLoginInPluginResponse
+	kindPacketLoginInStart
+	kindPacketLoginOutCompression
+	kindPacketLoginOutDisconnect
+	kindPacketLoginOutPluginRequest
+	kindPacketLoginOutSuccess
+	kindPacketPlayInAbilities
+	kindPacketPlayInChatCommand
+	kindPacketPlayInChatMessage
+	kindPacketPlayInConfigurationAcknowledged
+	kindPacketPlayInKeepAlive
+	kindPacketPlayInPluginMessage
+	kindPacketPlayInPosition
+	kindPacketPlayInPositionAndLook
+	kindPacketPlayInTabComplete
+	kindPacketPlayOutAbilities
+	kindPacketPlayOutActionBar
+	kindPacketPlayOutBossBar
+	kindPacketPlayOutChangeGameState
+	kindPacketPlayOutChatMessage
+	kindPacketPlayOutChunkData
+	kindPacketPlayOutClearTitles
+	kindPacketPlayOutDeclareCommands
+	kindPacketPlayOutDisconnect
+	kindPacketPlayOutDisplayScoreboard
+	kindPacketPlayOutJoinGame
+	kindPacketPlayOutKeepAlive
+	kindPacketPlayOutNamedSoundEffect
+	kindPacketPlayOutParticle
+	kindPacketPlayOutPluginMessage
+	kindPacketPlayOutPositionAndLook
+	kindPacketPlayOutScoreboardObjective
+	kindPacketPlayOutServerDifficulty
+	kindPacketPlayOutStartConfiguration
+	kindPacketPlayOutSubtitleText
+	kindPacketPlayOutTabComplete
+	kindPacketPlayOutTeams
+	kindPacketPlayOutTitle
+	kindPacketPlayOutTitleText
+	kindPacketPlayOutTitleTimes
+	kindPacketPlayOutUpdateScore
+	kindPacketStatusInPing
+	kindPacketStatusInRequest
+	kindPacketStatusOutPong
+	kindPacketStatusOutResponse
+	kindCount
+)
+
+var (
+	constructors = [kindCount]func() protocol.Packet{
+		kindPacketConfigurationInFinish:           func() protocol.Packet { return &PacketConfigurationInFinish{} },
+		kindPacketConfigurationInKeepAlive:        func() protocol.Packet { return &PacketConfigurationInKeepAlive{} },
+		kindPacketConfigurationInPluginMessage:    func() protocol.Packet { return &PacketConfigurationInPluginMessage{} },
+		kindPacketConfigurationInResourcePack:     func() protocol.Packet { return &PacketConfigurationInResourcePack{} },
+		kindPacketConfigurationOutDisconnect:      func() protocol.Packet { return &PacketConfigurationOutDisconnect{} },
+		kindPacketConfigurationOutFeatureFlags:    func() protocol.Packet { return &PacketConfigurationOutFeatureFlags{} },
+		kindPacketConfigurationOutFinish:          func() protocol.Packet { return &PacketConfigurationOutFinish{} },
+		kindPacketConfigurationOutKeepAlive:       func() protocol.Packet { return &PacketConfigurationOutKeepAlive{} },
+		kindPacketConfigurationOutPluginMessage:   func() protocol.Packet { return &PacketConfigurationOutPluginMessage{} },
+		kindPacketConfigurationOutRegistryData:    func() protocol.Packet { return &PacketConfigurationOutRegistryData{} },
+		kindPacketConfigurationOutResourcePack:    func() protocol.Packet { return &PacketConfigurationOutResourcePack{} },
+		kindPacketHandshakingStart:                func() protocol.Packet { return &PacketHandshakingStart{} },
+		kindPacketLoginInAcknowledged:             func() protocol.Packet { return &PacketLoginInAcknowledged{} },
+		kindPacketLoginInPluginResponse:           func() protocol.Packet { return &PacketLoginInPluginResponse{} },
+		kindPacketLoginInStart:                    func() protocol.Packet { return &PacketLoginInStart{} },
+		kindPacketLoginOutCompression:             func() protocol.Packet { return &PacketLoginOutCompression{} },
+		kindPacketLoginOutDisconnect:              func() protocol.Packet { return &PacketLoginOutDisconnect{} },
+		kindPacketLoginOutPluginRequest:           func() protocol.Packet { return &PacketLoginOutPluginRequest{} },
+		kindPacketLoginOutSuccess:                 func() protocol.Packet { return &PacketLoginOutSuccess{} },
+		kindPacketPlayInAbilities:                 func() protocol.Packet { return &PacketPlayInAbilities{} },
+		kindPacketPlayInChatCommand:               func() protocol.Packet { return &PacketPlayInChatCommand{} },
+		kindPacketPlayInChatMessage:               func() protocol.Packet { return &PacketPlayInChatMessage{} },
+		kindPacketPlayInConfigurationAcknowledged: func() protocol.Packet { return &PacketPlayInConfigurationAcknowledged{} },
+		kindPacketPlayInKeepAlive:                 func() protocol.Packet { return &PacketPlayInKeepAlive{} },
+		kindPacketPlayInPluginMessage:             func() protocol.Packet { return &PacketPlayInPluginMessage{} },
+		kindPacketPlayInPosition:                  func() protocol.Packet { return &PacketPlayInPosition{} },
+		kindPacketPlayInPositionAndLook:           func() protocol.Packet { return &PacketPlayInPositionAndLook{} },
+		kindPacketPlayInTabComplete:               func() protocol.Packet { return &PacketPlayInTabComplete{} },
+		kindPacketPlayOutAbilities:                func() protocol.Packet { return &PacketPlayOutAbilities{} },
+		kindPacketPlayOutActionBar:                func() protocol.Packet { return &PacketPlayOutActionBar{} },
+		kindPacketPlayOutBossBar:                  func() protocol.Packet { return &PacketPlayOutBossBar{} },
+		kindPacketPlayOutChangeGameState:          func() protocol.Packet { return &PacketPlayOutChangeGameState{} },
+		kindPacketPlayOutChatMessage:              func() protocol.Packet { return &PacketPlayOutChatMessage{} },
+		kindPacketPlayOutChunkData:                func() protocol.Packet { return &PacketPlayOutChunkData{} },
+		kindPacketPlayOutClearTitles:              func() protocol.Packet { return &PacketPlayOutClearTitles{} },
+		kindPacketPlayOutDeclareCommands:          func() protocol.Packet { return &PacketPlayOutDeclareCommands{} },
+		kindPacketPlayOutDisconnect:               func() protocol.Packet { return &PacketPlayOutDisconnect{} },
+		kindPacketPlayOutDisplayScoreboard:        func() protocol.Packet { return &PacketPlayOutDisplayScoreboard{} },
+		kindPacketPlayOutJoinGame:                 func() protocol.Packet { return &PacketPlayOutJoinGame{} },
+		kindPacketPlayOutKeepAlive:                func() protocol.Packet { return &PacketPlayOutKeepAlive{} },
+		kindPacketPlayOutNamedSoundEffect:         func() protocol.Packet { return &PacketPlayOutNamedSoundEffect{} },
+		kindPacketPlayOutParticle:                 func() protocol.Packet { return &PacketPlayOutParticle{} },
+		kindPacketPlayOutPluginMessage:            func() protocol.Packet { return &PacketPlayOutPluginMessage{} },
+		kindPacketPlayOutPositionAndLook:          func() protocol.Packet { return &PacketPlayOutPositionAndLook{} },
+		kindPacketPlayOutScoreboardObjective:      func() protocol.Packet { return &PacketPlayOutScoreboardObjective{} },
+		kindPacketPlayOutServerDifficulty:         func() protocol.Packet { return &PacketPlayOutServerDifficulty{} },
+		kindPacketPlayOutStartConfiguration:       func() protocol.Packet { return &PacketPlayOutStartConfiguration{} },
+		kindPacketPlayOutSubtitleText:             func() protocol.Packet { return &PacketPlayOutSubtitleText{} },
+		kindPacketPlayOutTabComplete:              func() protocol.Packet { return &PacketPlayOutTabComplete{} },
+		kindPacketPlayOutTeams:                    func() protocol.Packet { return &PacketPlayOutTeams{} },
+		kindPacketPlayOutTitle:                    func() protocol.Packet { return &PacketPlayOutTitle{} },
+		kindPacketPlayOutTitleText:                func() protocol.Packet { return &PacketPlayOutTitleText{} },
+		kindPacketPlayOutTitleTimes:               func() protocol.Packet { return &PacketPlayOutTitleTimes{} },
+		kindPacketPlayOutUpdateScore:              func() protocol.Packet { return &PacketPlayOutUpdateScore{} },
+		kindPacketStatusInPing:                    func() protocol.Packet { return &PacketStatusInPing{} },
+		kindPacketStatusInRequest:                 func() protocol.Packet { return &PacketStatusInRequest{} },
+		kindPacketStatusOutPong:                   func() protocol.Packet { return &PacketStatusOutPong{} },
+		kindPacketStatusOutResponse:               func() protocol.Packet { return &PacketStatusOutResponse{} },
+	}
+
+	kindNames = [kindCount]string{
+		kindPacketConfigurationInFinish:           "PacketConfigurationInFinish",
+		kindPacketConfigurationInKeepAlive:        "PacketConfigurationInKeepAlive",
+		kindPacketConfigurationInPluginMessage:    "PacketConfigurationInPluginMessage",
+		kindPacketConfigurationInResourcePack:     "PacketConfigurationInResourcePack",
+		kindPacketConfigurationOutDisconnect:      "PacketConfigurationOutDisconnect",
+		kindPacketConfigurationOutFeatureFlags:    "PacketConfigurationOutFeatureFlags",
+		kindPacketConfigurationOutFinish:          "PacketConfigurationOutFinish",
+		kindPacketConfigurationOutKeepAlive:       "PacketConfigurationOutKeepAlive",
+		kindPacketConfigurationOutPluginMessage:   "PacketConfigurationOutPluginMessage",
+		kindPacketConfigurationOutRegistryData:    "PacketConfigurationOutRegistryData",
+		kindPacketConfigurationOutResourcePack:    "PacketConfigurationOutResourcePack",
+		kindPacketHandshakingStart:                "PacketHandshakingStart",
+		kindPacketLoginInAcknowledged:             "PacketLoginInAcknowledged",
+		kindPacketLoginInPluginResponse:           "PacketLoginInPluginResponse",
+		kindPacketLoginInStart:                    "PacketLoginInStart",
+		kindPacketLoginOutCompression:             "PacketLoginOutCompression",
+		kindPacketLoginOutDisconnect:              "PacketLoginOutDisconnect",
+		kindPacketLoginOutPluginRequest:           "PacketLoginOutPluginRequest",
+		kindPacketLoginOutSuccess:                 "PacketLoginOutSuccess",
+		kindPacketPlayInAbilities:                 "PacketPlayInAbilities",
+		kindPacketPlayInChatCommand:               "PacketPlayInChatCommand",
+		kindPacketPlayInChatMessage:               "PacketPlayInChatMessage",
+		kindPacketPlayInConfigurationAcknowledged: "PacketPlayInConfigurationAcknowledged",
+		kindPacketPlayInKeepAlive:                 "PacketPlayInKeepAlive",
+		kindPacketPlayInPluginMessage:             "PacketPlayInPluginMessage",
+		kindPacketPlayInPosition:                  "PacketPlayInPosition",
+		kindPacketPlayInPositionAndLook:           "PacketPlayInPositionAndLook",
+		kindPacketPlayInTabComplete:               "PacketPlayInTabComplete",
+		kindPacketPlayOutAbilities:                "PacketPlayOutAbilities",
+		kindPacketPlayOutActionBar:                "PacketPlayOutActionBar",
+		kindPacketPlayOutBossBar:                  "PacketPlayOutBossBar",
+		kindPacketPlayOutChangeGameState:          "PacketPlayOutChangeGameState",
+		kindPacketPlayOutChatMessage:              "PacketPlayOutChatMessage",
+		kindPacketPlayOutChunkData:                "PacketPlayOutChunkData",
+		kindPacketPlayOutClearTitles:              "PacketPlayOutClearTitles",
+		kindPacketPlayOutDeclareCommands:          "PacketPlayOutDeclareCommands",
+		kindPacketPlayOutDisconnect:               "PacketPlayOutDisconnect",
+		kindPacketPlayOutDisplayScoreboard:        "PacketPlayOutDisplayScoreboard",
+		kindPacketPlayOutJoinGame:                 "PacketPlayOutJoinGame",
+		kindPacketPlayOutKeepAlive:                "PacketPlayOutKeepAlive",
+		kindPacketPlayOutNamedSoundEffect:         "PacketPlayOutNamedSoundEffect",
+		kindPacketPlayOutParticle:                 "PacketPlayOutParticle",
+		kindPacketPlayOutPluginMessage:            "PacketPlayOutPluginMessage",
+		kindPacketPlayOutPositionAndLook:          "PacketPlayOutPositionAndLook",
+		kindPacketPlayOutScoreboardObjective:      "PacketPlayOutScoreboardObjective",
+		kindPacketPlayOutServerDifficulty:         "PacketPlayOutServerDifficulty",
+		kindPacketPlayOutStartConfiguration:       "PacketPlayOutStartConfiguration",
+		kindPacketPlayOutSubtitleText:             "PacketPlayOutSubtitleText",
+		kindPacketPlayOutTabComplete:              "PacketPlayOutTabComplete",
+		kindPacketPlayOutTeams:                    "PacketPlayOutTeams",
+		kindPacketPlayOutTitle:                    "PacketPlayOutTitle",
+		kindPacketPlayOutTitleText:                "PacketPlayOutTitleText",
+		kindPacketPlayOutTitleTimes:               "PacketPlayOutTitleTimes",
+		kindPacketPlayOutUpdateScore:              "PacketPlayOutUpdateScore",
+		kindPacketStatusInPing:                    "PacketStatusInPing",
+		kindPacketStatusInRequest:                 "PacketStatusInRequest",
+		kindPacketStatusOutPong:                   "PacketStatusOutPong",
+		kindPacketStatusOutResponse:               "PacketStatusOutResponse",
+	}
+
+	packetIDs = map[protocol.Protocol]map[protocol.State]map[protocol.Direction]map[kind]int32{
+		protocol.Unknown: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+		},
+		protocol.V1_8: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutKeepAlive:           0x00,
+					kindPacketPlayOutJoinGame:            0x01,
+					kindPacketPlayOutChatMessage:         0x02,
+					kindPacketPlayOutPositionAndLook:     0x08,
+					kindPacketPlayOutNamedSoundEffect:    0x29,
+					kindPacketPlayOutParticle:            0x2A,
+					kindPacketPlayOutChangeGameState:     0x2B,
+					kindPacketPlayOutAbilities:           0x39,
+					kindPacketPlayOutTabComplete:         0x3A,
+					kindPacketPlayOutScoreboardObjective: 0x3B,
+					kindPacketPlayOutUpdateScore:         0x3C,
+					kindPacketPlayOutDisplayScoreboard:   0x3D,
+					kindPacketPlayOutTeams:               0x3E,
+					kindPacketPlayOutPluginMessage:       0x3F,
+					kindPacketPlayOutDisconnect:          0x40,
+					kindPacketPlayOutServerDifficulty:    0x41,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInKeepAlive:       0x00,
+					kindPacketPlayInChatMessage:     0x01,
+					kindPacketPlayInPosition:        0x04,
+					kindPacketPlayInPositionAndLook: 0x06,
+					kindPacketPlayInAbilities:       0x13,
+					kindPacketPlayInTabComplete:     0x14,
+					kindPacketPlayInPluginMessage:   0x17,
+				},
+			},
+		},
+		protocol.V1_9: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_9_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_9_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_9_3: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_10: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_11: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_11_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x38,
+					kindPacketPlayOutScoreboardObjective: 0x3F,
+					kindPacketPlayOutTeams:               0x41,
+					kindPacketPlayOutUpdateScore:         0x42,
+					kindPacketPlayOutTitle:               0x45,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0C,
+					kindPacketPlayInPositionAndLook: 0x0D,
+					kindPacketPlayInAbilities:       0x12,
+				},
+			},
+		},
+		protocol.V1_12: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2B,
+					kindPacketPlayOutPositionAndLook:     0x2E,
+					kindPacketPlayOutDisplayScoreboard:   0x3A,
+					kindPacketPlayOutScoreboardObjective: 0x41,
+					kindPacketPlayOutTeams:               0x43,
+					kindPacketPlayOutUpdateScore:         0x44,
+					kindPacketPlayOutTitle:               0x47,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x02,
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0C,
+					kindPacketPlayInPosition:        0x0E,
+					kindPacketPlayInPositionAndLook: 0x0F,
+					kindPacketPlayInAbilities:       0x13,
+				},
+			},
+		},
+		protocol.V1_12_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2C,
+					kindPacketPlayOutPositionAndLook:     0x2F,
+					kindPacketPlayOutDisplayScoreboard:   0x3B,
+					kindPacketPlayOutScoreboardObjective: 0x42,
+					kindPacketPlayOutTeams:               0x44,
+					kindPacketPlayOutUpdateScore:         0x45,
+					kindPacketPlayOutTitle:               0x48,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0D,
+					kindPacketPlayInPositionAndLook: 0x0E,
+					kindPacketPlayInAbilities:       0x13,
+				},
+			},
+		},
+		protocol.V1_12_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:  0x00,
+					kindPacketLoginOutSuccess:     0x02,
+					kindPacketLoginOutCompression: 0x03,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart: 0x00,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2C,
+					kindPacketPlayOutPositionAndLook:     0x2F,
+					kindPacketPlayOutDisplayScoreboard:   0x3B,
+					kindPacketPlayOutScoreboardObjective: 0x42,
+					kindPacketPlayOutTeams:               0x44,
+					kindPacketPlayOutUpdateScore:         0x45,
+					kindPacketPlayOutTitle:               0x48,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInTabComplete:     0x01,
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInPluginMessage:   0x09,
+					kindPacketPlayInKeepAlive:       0x0B,
+					kindPacketPlayInPosition:        0x0D,
+					kindPacketPlayInPositionAndLook: 0x0E,
+					kindPacketPlayInAbilities:       0x13,
+				},
+			},
+		},
+		protocol.V1_13: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x19,
+					kindPacketPlayOutNamedSoundEffect:    0x1A,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x20,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x2E,
+					kindPacketPlayOutPositionAndLook:     0x32,
+					kindPacketPlayOutDisplayScoreboard:   0x3E,
+					kindPacketPlayOutScoreboardObjective: 0x45,
+					kindPacketPlayOutTeams:               0x47,
+					kindPacketPlayOutUpdateScore:         0x48,
+					kindPacketPlayOutTitle:               0x4B,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInTabComplete:     0x05,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0E,
+					kindPacketPlayInPosition:        0x10,
+					kindPacketPlayInPositionAndLook: 0x11,
+					kindPacketPlayInAbilities:       0x17,
+				},
+			},
+		},
+		protocol.V1_13_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x19,
+					kindPacketPlayOutNamedSoundEffect:    0x1A,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x20,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x2E,
+					kindPacketPlayOutPositionAndLook:     0x32,
+					kindPacketPlayOutDisplayScoreboard:   0x3E,
+					kindPacketPlayOutScoreboardObjective: 0x45,
+					kindPacketPlayOutTeams:               0x47,
+					kindPacketPlayOutUpdateScore:         0x48,
+					kindPacketPlayOutTitle:               0x4B,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInTabComplete:     0x05,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0E,
+					kindPacketPlayInPosition:        0x10,
+					kindPacketPlayInPositionAndLook: 0x11,
+					kindPacketPlayInAbilities:       0x17,
+				},
+			},
+		},
+		protocol.V1_13_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x19,
+					kindPacketPlayOutNamedSoundEffect:    0x1A,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x20,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x2E,
+					kindPacketPlayOutPositionAndLook:     0x32,
+					kindPacketPlayOutDisplayScoreboard:   0x3E,
+					kindPacketPlayOutScoreboardObjective: 0x45,
+					kindPacketPlayOutTeams:               0x47,
+					kindPacketPlayOutUpdateScore:         0x48,
+					kindPacketPlayOutTitle:               0x4B,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x02,
+					kindPacketPlayInTabComplete:     0x05,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0E,
+					kindPacketPlayInPosition:        0x10,
+					kindPacketPlayInPositionAndLook: 0x11,
+					kindPacketPlayInAbilities:       0x17,
+				},
+			},
+		},
+		protocol.V1_14: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x42,
+					kindPacketPlayOutScoreboardObjective: 0x49,
+					kindPacketPlayOutTeams:               0x4B,
+					kindPacketPlayOutUpdateScore:         0x4C,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_14_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x42,
+					kindPacketPlayOutScoreboardObjective: 0x49,
+					kindPacketPlayOutTeams:               0x4B,
+					kindPacketPlayOutUpdateScore:         0x4C,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_14_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x42,
+					kindPacketPlayOutScoreboardObjective: 0x49,
+					kindPacketPlayOutTeams:               0x4B,
+					kindPacketPlayOutUpdateScore:         0x4C,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_14_3: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x42,
+					kindPacketPlayOutScoreboardObjective: 0x49,
+					kindPacketPlayOutTeams:               0x4B,
+					kindPacketPlayOutUpdateScore:         0x4C,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_14_4: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x42,
+					kindPacketPlayOutScoreboardObjective: 0x49,
+					kindPacketPlayOutTeams:               0x4B,
+					kindPacketPlayOutUpdateScore:         0x4C,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_15: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x19,
+					kindPacketPlayOutNamedSoundEffect:    0x1A,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x1F,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x36,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x50,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_15_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x19,
+					kindPacketPlayOutNamedSoundEffect:    0x1A,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x1F,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x36,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x50,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_15_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x19,
+					kindPacketPlayOutNamedSoundEffect:    0x1A,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x1F,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x36,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x50,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_16: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x10,
+					kindPacketPlayInPosition:        0x12,
+					kindPacketPlayInPositionAndLook: 0x13,
+					kindPacketPlayInAbilities:       0x1A,
+				},
+			},
+		},
+		protocol.V1_16_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x35,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x10,
+					kindPacketPlayInPosition:        0x12,
+					kindPacketPlayInPositionAndLook: 0x13,
+					kindPacketPlayInAbilities:       0x1A,
+				},
+			},
+		},
+		protocol.V1_16_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x0F,
+					kindPacketPlayOutDeclareCommands:     0x10,
+					kindPacketPlayOutPluginMessage:       0x17,
+					kindPacketPlayOutNamedSoundEffect:    0x18,
+					kindPacketPlayOutDisconnect:          0x19,
+					kindPacketPlayOutChangeGameState:     0x1D,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutChunkData:           0x20,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x24,
+					kindPacketPlayOutAbilities:           0x30,
+					kindPacketPlayOutPositionAndLook:     0x34,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x10,
+					kindPacketPlayInPosition:        0x12,
+					kindPacketPlayInPositionAndLook: 0x13,
+					kindPacketPlayInAbilities:       0x1A,
+				},
+			},
+		},
+		protocol.V1_16_3: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x0F,
+					kindPacketPlayOutDeclareCommands:     0x10,
+					kindPacketPlayOutPluginMessage:       0x17,
+					kindPacketPlayOutNamedSoundEffect:    0x18,
+					kindPacketPlayOutDisconnect:          0x19,
+					kindPacketPlayOutChangeGameState:     0x1D,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutChunkData:           0x20,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x24,
+					kindPacketPlayOutAbilities:           0x30,
+					kindPacketPlayOutPositionAndLook:     0x34,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x10,
+					kindPacketPlayInPosition:        0x12,
+					kindPacketPlayInPositionAndLook: 0x13,
+					kindPacketPlayInAbilities:       0x1A,
+				},
+			},
+		},
+		protocol.V1_16_4: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0C,
+					kindPacketPlayOutServerDifficulty:    0x0D,
+					kindPacketPlayOutChatMessage:         0x0E,
+					kindPacketPlayOutTabComplete:         0x0F,
+					kindPacketPlayOutDeclareCommands:     0x10,
+					kindPacketPlayOutPluginMessage:       0x17,
+					kindPacketPlayOutNamedSoundEffect:    0x18,
+					kindPacketPlayOutDisconnect:          0x19,
+					kindPacketPlayOutChangeGameState:     0x1D,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutChunkData:           0x20,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x24,
+					kindPacketPlayOutAbilities:           0x30,
+					kindPacketPlayOutPositionAndLook:     0x34,
+					kindPacketPlayOutDisplayScoreboard:   0x43,
+					kindPacketPlayOutScoreboardObjective: 0x4A,
+					kindPacketPlayOutTeams:               0x4C,
+					kindPacketPlayOutUpdateScore:         0x4D,
+					kindPacketPlayOutTitle:               0x4F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0B,
+					kindPacketPlayInKeepAlive:       0x10,
+					kindPacketPlayInPosition:        0x12,
+					kindPacketPlayInPositionAndLook: 0x13,
+					kindPacketPlayInAbilities:       0x1A,
+				},
+			},
+		},
+		protocol.V1_17: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutClearTitles:         0x10,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutChunkData:           0x22,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x38,
+					kindPacketPlayOutActionBar:           0x41,
+					kindPacketPlayOutDisplayScoreboard:   0x4C,
+					kindPacketPlayOutScoreboardObjective: 0x53,
+					kindPacketPlayOutTeams:               0x55,
+					kindPacketPlayOutUpdateScore:         0x56,
+					kindPacketPlayOutSubtitleText:        0x57,
+					kindPacketPlayOutTitleText:           0x59,
+					kindPacketPlayOutTitleTimes:          0x5A,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_17_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutClearTitles:         0x10,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutChunkData:           0x22,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x38,
+					kindPacketPlayOutActionBar:           0x41,
+					kindPacketPlayOutDisplayScoreboard:   0x4C,
+					kindPacketPlayOutScoreboardObjective: 0x53,
+					kindPacketPlayOutTeams:               0x55,
+					kindPacketPlayOutUpdateScore:         0x56,
+					kindPacketPlayOutSubtitleText:        0x57,
+					kindPacketPlayOutTitleText:           0x59,
+					kindPacketPlayOutTitleTimes:          0x5A,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_18: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutClearTitles:         0x10,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutChunkData:           0x22,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x38,
+					kindPacketPlayOutActionBar:           0x41,
+					kindPacketPlayOutDisplayScoreboard:   0x4C,
+					kindPacketPlayOutScoreboardObjective: 0x53,
+					kindPacketPlayOutTeams:               0x55,
+					kindPacketPlayOutUpdateScore:         0x56,
+					kindPacketPlayOutSubtitleText:        0x58,
+					kindPacketPlayOutTitleText:           0x5A,
+					kindPacketPlayOutTitleTimes:          0x5B,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_18_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0D,
+					kindPacketPlayOutServerDifficulty:    0x0E,
+					kindPacketPlayOutChatMessage:         0x0F,
+					kindPacketPlayOutClearTitles:         0x10,
+					kindPacketPlayOutTabComplete:         0x11,
+					kindPacketPlayOutDeclareCommands:     0x12,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutNamedSoundEffect:    0x19,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1E,
+					kindPacketPlayOutKeepAlive:           0x21,
+					kindPacketPlayOutChunkData:           0x22,
+					kindPacketPlayOutParticle:            0x24,
+					kindPacketPlayOutJoinGame:            0x26,
+					kindPacketPlayOutAbilities:           0x32,
+					kindPacketPlayOutPositionAndLook:     0x38,
+					kindPacketPlayOutActionBar:           0x41,
+					kindPacketPlayOutDisplayScoreboard:   0x4C,
+					kindPacketPlayOutScoreboardObjective: 0x53,
+					kindPacketPlayOutTeams:               0x55,
+					kindPacketPlayOutUpdateScore:         0x56,
+					kindPacketPlayOutSubtitleText:        0x58,
+					kindPacketPlayOutTitleText:           0x5A,
+					kindPacketPlayOutTitleTimes:          0x5B,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatMessage:     0x03,
+					kindPacketPlayInTabComplete:     0x06,
+					kindPacketPlayInPluginMessage:   0x0A,
+					kindPacketPlayInKeepAlive:       0x0F,
+					kindPacketPlayInPosition:        0x11,
+					kindPacketPlayInPositionAndLook: 0x12,
+					kindPacketPlayInAbilities:       0x19,
+				},
+			},
+		},
+		protocol.V1_19: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0A,
+					kindPacketPlayOutServerDifficulty:    0x0B,
+					kindPacketPlayOutClearTitles:         0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutDeclareCommands:     0x0F,
+					kindPacketPlayOutPluginMessage:       0x15,
+					kindPacketPlayOutNamedSoundEffect:    0x16,
+					kindPacketPlayOutDisconnect:          0x17,
+					kindPacketPlayOutChangeGameState:     0x1B,
+					kindPacketPlayOutKeepAlive:           0x1E,
+					kindPacketPlayOutChunkData:           0x1F,
+					kindPacketPlayOutParticle:            0x21,
+					kindPacketPlayOutJoinGame:            0x23,
+					kindPacketPlayOutAbilities:           0x2F,
+					kindPacketPlayOutPositionAndLook:     0x36,
+					kindPacketPlayOutActionBar:           0x40,
+					kindPacketPlayOutDisplayScoreboard:   0x4C,
+					kindPacketPlayOutScoreboardObjective: 0x53,
+					kindPacketPlayOutTeams:               0x55,
+					kindPacketPlayOutUpdateScore:         0x56,
+					kindPacketPlayOutSubtitleText:        0x58,
+					kindPacketPlayOutTitleText:           0x5A,
+					kindPacketPlayOutTitleTimes:          0x5B,
+					kindPacketPlayOutChatMessage:         0x5F,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatCommand:     0x03,
+					kindPacketPlayInChatMessage:     0x04,
+					kindPacketPlayInTabComplete:     0x08,
+					kindPacketPlayInPluginMessage:   0x0C,
+					kindPacketPlayInKeepAlive:       0x11,
+					kindPacketPlayInPosition:        0x13,
+					kindPacketPlayInPositionAndLook: 0x14,
+					kindPacketPlayInAbilities:       0x1B,
+				},
+			},
+		},
+		protocol.V1_19_1: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0A,
+					kindPacketPlayOutServerDifficulty:    0x0B,
+					kindPacketPlayOutClearTitles:         0x0D,
+					kindPacketPlayOutTabComplete:         0x0E,
+					kindPacketPlayOutDeclareCommands:     0x0F,
+					kindPacketPlayOutPluginMessage:       0x16,
+					kindPacketPlayOutNamedSoundEffect:    0x17,
+					kindPacketPlayOutDisconnect:          0x19,
+					kindPacketPlayOutChangeGameState:     0x1D,
+					kindPacketPlayOutKeepAlive:           0x20,
+					kindPacketPlayOutChunkData:           0x21,
+					kindPacketPlayOutParticle:            0x23,
+					kindPacketPlayOutJoinGame:            0x25,
+					kindPacketPlayOutAbilities:           0x31,
+					kindPacketPlayOutPositionAndLook:     0x39,
+					kindPacketPlayOutActionBar:           0x43,
+					kindPacketPlayOutDisplayScoreboard:   0x4F,
+					kindPacketPlayOutScoreboardObjective: 0x56,
+					kindPacketPlayOutTeams:               0x58,
+					kindPacketPlayOutUpdateScore:         0x59,
+					kindPacketPlayOutSubtitleText:        0x5B,
+					kindPacketPlayOutTitleText:           0x5D,
+					kindPacketPlayOutTitleTimes:          0x5E,
+					kindPacketPlayOutChatMessage:         0x62,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatCommand:     0x04,
+					kindPacketPlayInChatMessage:     0x05,
+					kindPacketPlayInTabComplete:     0x09,
+					kindPacketPlayInPluginMessage:   0x0D,
+					kindPacketPlayInKeepAlive:       0x12,
+					kindPacketPlayInPosition:        0x14,
+					kindPacketPlayInPositionAndLook: 0x15,
+					kindPacketPlayInAbilities:       0x1C,
+				},
+			},
+		},
+		protocol.V1_19_3: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0A,
+					kindPacketPlayOutServerDifficulty:    0x0B,
+					kindPacketPlayOutClearTitles:         0x0C,
+					kindPacketPlayOutTabComplete:         0x0D,
+					kindPacketPlayOutDeclareCommands:     0x0E,
+					kindPacketPlayOutPluginMessage:       0x15,
+					kindPacketPlayOutDisconnect:          0x17,
+					kindPacketPlayOutChangeGameState:     0x1C,
+					kindPacketPlayOutKeepAlive:           0x1F,
+					kindPacketPlayOutChunkData:           0x20,
+					kindPacketPlayOutParticle:            0x22,
+					kindPacketPlayOutJoinGame:            0x24,
+					kindPacketPlayOutAbilities:           0x30,
+					kindPacketPlayOutPositionAndLook:     0x38,
+					kindPacketPlayOutActionBar:           0x42,
+					kindPacketPlayOutDisplayScoreboard:   0x4D,
+					kindPacketPlayOutScoreboardObjective: 0x54,
+					kindPacketPlayOutTeams:               0x56,
+					kindPacketPlayOutUpdateScore:         0x57,
+					kindPacketPlayOutSubtitleText:        0x59,
+					kindPacketPlayOutTitleText:           0x5B,
+					kindPacketPlayOutTitleTimes:          0x5C,
+					kindPacketPlayOutNamedSoundEffect:    0x5E,
+					kindPacketPlayOutChatMessage:         0x60,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatCommand:     0x04,
+					kindPacketPlayInChatMessage:     0x05,
+					kindPacketPlayInTabComplete:     0x08,
+					kindPacketPlayInPluginMessage:   0x0C,
+					kindPacketPlayInKeepAlive:       0x11,
+					kindPacketPlayInPosition:        0x13,
+					kindPacketPlayInPositionAndLook: 0x14,
+					kindPacketPlayInAbilities:       0x1B,
+				},
+			},
+		},
+		protocol.V1_19_4: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0B,
+					kindPacketPlayOutServerDifficulty:    0x0C,
+					kindPacketPlayOutClearTitles:         0x0E,
+					kindPacketPlayOutTabComplete:         0x0F,
+					kindPacketPlayOutDeclareCommands:     0x10,
+					kindPacketPlayOutPluginMessage:       0x17,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1F,
+					kindPacketPlayOutKeepAlive:           0x23,
+					kindPacketPlayOutChunkData:           0x24,
+					kindPacketPlayOutParticle:            0x26,
+					kindPacketPlayOutJoinGame:            0x28,
+					kindPacketPlayOutAbilities:           0x34,
+					kindPacketPlayOutPositionAndLook:     0x3C,
+					kindPacketPlayOutActionBar:           0x46,
+					kindPacketPlayOutDisplayScoreboard:   0x51,
+					kindPacketPlayOutScoreboardObjective: 0x58,
+					kindPacketPlayOutTeams:               0x5A,
+					kindPacketPlayOutUpdateScore:         0x5B,
+					kindPacketPlayOutSubtitleText:        0x5D,
+					kindPacketPlayOutTitleText:           0x5F,
+					kindPacketPlayOutTitleTimes:          0x60,
+					kindPacketPlayOutNamedSoundEffect:    0x62,
+					kindPacketPlayOutChatMessage:         0x64,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatCommand:     0x04,
+					kindPacketPlayInChatMessage:     0x05,
+					kindPacketPlayInTabComplete:     0x09,
+					kindPacketPlayInPluginMessage:   0x0D,
+					kindPacketPlayInKeepAlive:       0x12,
+					kindPacketPlayInPosition:        0x14,
+					kindPacketPlayInPositionAndLook: 0x15,
+					kindPacketPlayInAbilities:       0x1C,
+				},
+			},
+		},
+		protocol.V1_20: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0B,
+					kindPacketPlayOutServerDifficulty:    0x0C,
+					kindPacketPlayOutClearTitles:         0x0E,
+					kindPacketPlayOutTabComplete:         0x0F,
+					kindPacketPlayOutDeclareCommands:     0x10,
+					kindPacketPlayOutPluginMessage:       0x17,
+					kindPacketPlayOutDisconnect:          0x1A,
+					kindPacketPlayOutChangeGameState:     0x1F,
+					kindPacketPlayOutKeepAlive:           0x23,
+					kindPacketPlayOutChunkData:           0x24,
+					kindPacketPlayOutParticle:            0x26,
+					kindPacketPlayOutJoinGame:            0x28,
+					kindPacketPlayOutAbilities:           0x34,
+					kindPacketPlayOutPositionAndLook:     0x3C,
+					kindPacketPlayOutActionBar:           0x46,
+					kindPacketPlayOutDisplayScoreboard:   0x51,
+					kindPacketPlayOutScoreboardObjective: 0x58,
+					kindPacketPlayOutTeams:               0x5A,
+					kindPacketPlayOutUpdateScore:         0x5B,
+					kindPacketPlayOutSubtitleText:        0x5D,
+					kindPacketPlayOutTitleText:           0x5F,
+					kindPacketPlayOutTitleTimes:          0x60,
+					kindPacketPlayOutNamedSoundEffect:    0x62,
+					kindPacketPlayOutChatMessage:         0x64,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatCommand:     0x04,
+					kindPacketPlayInChatMessage:     0x05,
+					kindPacketPlayInTabComplete:     0x09,
+					kindPacketPlayInPluginMessage:   0x0D,
+					kindPacketPlayInKeepAlive:       0x12,
+					kindPacketPlayInPosition:        0x14,
+					kindPacketPlayInPositionAndLook: 0x15,
+					kindPacketPlayInAbilities:       0x1C,
+				},
+			},
+		},
+		protocol.V1_20_2: {
+			protocol.Handshaking: {
+				protocol.ServerBound: {
+					kindPacketHandshakingStart: 0x00,
+				},
+			},
+			protocol.Status: {
+				protocol.ClientBound: {
+					kindPacketStatusOutResponse: 0x00,
+					kindPacketStatusOutPong:     0x01,
+				},
+				protocol.ServerBound: {
+					kindPacketStatusInRequest: 0x00,
+					kindPacketStatusInPing:    0x01,
+				},
+			},
+			protocol.Login: {
+				protocol.ClientBound: {
+					kindPacketLoginOutDisconnect:    0x00,
+					kindPacketLoginOutSuccess:       0x02,
+					kindPacketLoginOutCompression:   0x03,
+					kindPacketLoginOutPluginRequest: 0x04,
+				},
+				protocol.ServerBound: {
+					kindPacketLoginInStart:          0x00,
+					kindPacketLoginInPluginResponse: 0x02,
+					kindPacketLoginInAcknowledged:   0x03,
+				},
+			},
+			protocol.Configuration: {
+				protocol.ClientBound: {
+					kindPacketConfigurationOutPluginMessage: 0x00,
+					kindPacketConfigurationOutDisconnect:    0x01,
+					kindPacketConfigurationOutFinish:        0x02,
+					kindPacketConfigurationOutKeepAlive:     0x03,
+					kindPacketConfigurationOutRegistryData:  0x05,
+					kindPacketConfigurationOutResourcePack:  0x06,
+					kindPacketConfigurationOutFeatureFlags:  0x07,
+				},
+				protocol.ServerBound: {
+					kindPacketConfigurationInPluginMessage: 0x01,
+					kindPacketConfigurationInFinish:        0x02,
+					kindPacketConfigurationInKeepAlive:     0x03,
+					kindPacketConfigurationInResourcePack:  0x05,
+				},
+			},
+			protocol.Play: {
+				protocol.ClientBound: {
+					kindPacketPlayOutBossBar:             0x0A,
+					kindPacketPlayOutServerDifficulty:    0x0B,
+					kindPacketPlayOutClearTitles:         0x0F,
+					kindPacketPlayOutTabComplete:         0x10,
+					kindPacketPlayOutDeclareCommands:     0x11,
+					kindPacketPlayOutPluginMessage:       0x18,
+					kindPacketPlayOutDisconnect:          0x1B,
+					kindPacketPlayOutChangeGameState:     0x20,
+					kindPacketPlayOutKeepAlive:           0x24,
+					kindPacketPlayOutChunkData:           0x25,
+					kindPacketPlayOutParticle:            0x27,
+					kindPacketPlayOutJoinGame:            0x29,
+					kindPacketPlayOutAbilities:           0x36,
+					kindPacketPlayOutPositionAndLook:     0x3E,
+					kindPacketPlayOutActionBar:           0x48,
+					kindPacketPlayOutDisplayScoreboard:   0x53,
+					kindPacketPlayOutScoreboardObjective: 0x5A,
+					kindPacketPlayOutTeams:               0x5C,
+					kindPacketPlayOutUpdateScore:         0x5D,
+					kindPacketPlayOutSubtitleText:        0x5F,
+					kindPacketPlayOutTitleText:           0x61,
+					kindPacketPlayOutTitleTimes:          0x62,
+					kindPacketPlayOutNamedSoundEffect:    0x64,
+					kindPacketPlayOutStartConfiguration:  0x65,
+					kindPacketPlayOutChatMessage:         0x67,
+				},
+				protocol.ServerBound: {
+					kindPacketPlayInChatCommand:               0x04,
+					kindPacketPlayInChatMessage:               0x05,
+					kindPacketPlayInTabComplete:               0x0A,
+					kindPacketPlayInConfigurationAcknowledged: 0x0B,
+					kindPacketPlayInPluginMessage:             0x0F,
+					kindPacketPlayInKeepAlive:                 0x14,
+					kindPacketPlayInPosition:                  0x16,
+					kindPacketPlayInPositionAndLook:           0x17,
+					kindPacketPlayInAbilities:                 0x1F,
+				},
+			},
+		},
+	}
+)
+
+func kindOfPacket(packet protocol.Packet) kind {
+	switch packet.(type) {
+	case *PacketConfigurationInFinish:
+		return kindPacketConfigurationInFinish
+	case *PacketConfigurationInKeepAlive:
+		return kindPacketConfigurationInKeepAlive
+	case *PacketConfigurationInPluginMessage:
+		return kindPacketConfigurationInPluginMessage
+	case *PacketConfigurationInResourcePack:
+		return kindPacketConfigurationInResourcePack
+	case *PacketConfigurationOutDisconnect:
+		return kindPacketConfigurationOutDisconnect
+	case *PacketConfigurationOutFeatureFlags:
+		return kindPacketConfigurationOutFeatureFlags
+	case *PacketConfigurationOutFinish:
+		return kindPacketConfigurationOutFinish
+	case *PacketConfigurationOutKeepAlive:
+		return kindPacketConfigurationOutKeepAlive
+	case *PacketConfigurationOutPluginMessage:
+		return kindPacketConfigurationOutPluginMessage
+	case *PacketConfigurationOutRegistryData:
+		return kindPacketConfigurationOutRegistryData
+	case *PacketConfigurationOutResourcePack:
+		return kindPacketConfigurationOutResourcePack
+	case *PacketHandshakingStart:
+		return kindPacketHandshakingStart
+	case *PacketLoginInAcknowledged:
+		return kindPacketLoginInAcknowledged
+	case *PacketLoginInPluginResponse:
+		return kindPacketLoginInPluginResponse
+	case *PacketLoginInStart:
+		return kindPacketLoginInStart
+	case *PacketLoginOutCompression:
+		return kindPacketLoginOutCompression
+	case *PacketLoginOutDisconnect:
+		return kindPacketLoginOutDisconnect
+	case *PacketLoginOutPluginRequest:
+		return kindPacketLoginOutPluginRequest
+	case *PacketLoginOutSuccess:
+		return kindPacketLoginOutSuccess
+	case *PacketPlayInAbilities:
+		return kindPacketPlayInAbilities
+	case *PacketPlayInChatCommand:
+		return kindPacketPlayInChatCommand
+	case *PacketPlayInChatMessage:
+		return kindPacketPlayInChatMessage
+	case *PacketPlayInConfigurationAcknowledged:
+		return kindPacketPlayInConfigurationAcknowledged
+	case *PacketPlayInKeepAlive:
+		return kindPacketPlayInKeepAlive
+	case *PacketPlayInPluginMessage:
+		return kindPacketPlayInPluginMessage
+	case *PacketPlayInPosition:
+		return kindPacketPlayInPosition
+	case *PacketPlayInPositionAndLook:
+		return kindPacketPlayInPositionAndLook
+	case *PacketPlayInTabComplete:
+		return kindPacketPlayInTabComplete
+	case *PacketPlayOutAbilities:
+		return kindPacketPlayOutAbilities
+	case *PacketPlayOutActionBar:
+		return kindPacketPlayOutActionBar
+	case *PacketPlayOutBossBar:
+		return kindPacketPlayOutBossBar
+	case *PacketPlayOutChangeGameState:
+		return kindPacketPlayOutChangeGameState
+	case *PacketPlayOutChatMessage:
+		return kindPacketPlayOutChatMessage
+	case *PacketPlayOutChunkData:
+		return kindPacketPlayOutChunkData
+	case *PacketPlayOutClearTitles:
+		return kindPacketPlayOutClearTitles
+	case *PacketPlayOutDeclareCommands:
+		return kindPacketPlayOutDeclareCommands
+	case *PacketPlayOutDisconnect:
+		return kindPacketPlayOutDisconnect
+	case *PacketPlayOutDisplayScoreboard:
+		return kindPacketPlayOutDisplayScoreboard
+	case *PacketPlayOutJoinGame:
+		return kindPacketPlayOutJoinGame
+	case *PacketPlayOutKeepAlive:
+		return kindPacketPlayOutKeepAlive
+	case *PacketPlayOutNamedSoundEffect:
+		return kindPacketPlayOutNamedSoundEffect
+	case *PacketPlayOutParticle:
+		return kindPacketPlayOutParticle
+	case *PacketPlayOutPluginMessage:
+		return kindPacketPlayOutPluginMessage
+	case *PacketPlayOutPositionAndLook:
+		return kindPacketPlayOutPositionAndLook
+	case *PacketPlayOutScoreboardObjective:
+		return kindPacketPlayOutScoreboardObjective
+	case *PacketPlayOutServerDifficulty:
+		return kindPacketPlayOutServerDifficulty
+	case *PacketPlayOutStartConfiguration:
+		return kindPacketPlayOutStartConfiguration
+	case *PacketPlayOutSubtitleText:
+		return kindPacketPlayOutSubtitleText
+	case *PacketPlayOutTabComplete:
+		return kindPacketPlayOutTabComplete
+	case *PacketPlayOutTeams:
+		return kindPacketPlayOutTeams
+	case *PacketPlayOutTitle:
+		return kindPacketPlayOutTitle
+	case *PacketPlayOutTitleText:
+		return kindPacketPlayOutTitleText
+	case *PacketPlayOutTitleTimes:
+		return kindPacketPlayOutTitleTimes
+	case *PacketPlayOutUpdateScore:
+		return kindPacketPlayOutUpdateScore
+	case *PacketStatusInPing:
+		return kindPacketStatusInPing
+	case *PacketStatusInRequest:
+		return kindPacketStatusInRequest
+	case *PacketStatusOutPong:
+		return kindPacketStatusOutPong
+	case *PacketStatusOutResponse:
+		return kindPacketStatusOutResponse
+	}
+	return -1
+}
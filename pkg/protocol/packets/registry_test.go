@@ -0,0 +1,90 @@
+package packets
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	for _, proto := range append([]protocol.Protocol{protocol.Unknown}, protocol.SupportedProtocols...) {
+		if _, ok := tables[proto]; !ok {
+			t.Errorf("protocol %d has no packets", proto)
+			continue
+		}
+
+		for state, directions := range tables[proto] {
+			for direction, table := range directions {
+				for pKind, pID := range table.ids {
+					if pID >= 0 && table.byID[pID] != kind(pKind) {
+						t.Errorf("%d %s %s: %s has id 0x%02X, which maps back to %d", proto, state, direction, kindNames[pKind], pID, table.byID[pID])
+					}
+				}
+
+				for pID, pKind := range table.byID {
+					if pKind < 0 {
+						continue
+					}
+
+					if table.ids[pKind] != int32(pID) {
+						t.Errorf("%d %s %s: id 0x%02X is %s, which maps back to 0x%02X", proto, state, direction, pID, kindNames[pKind], table.ids[pKind])
+					}
+
+					packet, err := Get(proto, state, direction, int32(pID))
+					if err != nil {
+						t.Errorf("%d %s %s: %v", proto, state, direction, err)
+						continue
+					}
+					if name := reflect.TypeOf(packet).Elem().Name(); name != kindNames[pKind] || Name(packet) != name {
+						t.Errorf("%d %s %s: Get(0x%02X) = %T, want %s", proto, state, direction, pID, packet, kindNames[pKind])
+					}
+
+					// Packets look their own id up, so this catches one registered under the wrong state or direction
+					if id, err := packet.GetID(proto); err != nil || id != int32(pID) {
+						t.Errorf("%d %s %s: %T.GetID() = 0x%02X, %v, want 0x%02X", proto, state, direction, packet, id, err, pID)
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Get(protocol.V1_16_4, protocol.Play, protocol.ServerBound, 0x13); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetID(b *testing.B) {
+	packet := &PacketPlayOutKeepAlive{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetID(protocol.V1_16_4, protocol.Play, protocol.ClientBound, packet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTable_New(b *testing.B) {
+	table := Lookup(protocol.V1_16_4, protocol.Play, protocol.ServerBound)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := table.New(0x13); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTable_ID(b *testing.B) {
+	table := Lookup(protocol.V1_16_4, protocol.Play, protocol.ClientBound)
+	packet := &PacketPlayOutKeepAlive{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := table.ID(packet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package particles
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+)
+
+var (
+	//go:embed particles.json
+	particlesFile []byte
+
+	particles map[string]map[int]int
+)
+
+func init() {
+	err := json.Unmarshal(particlesFile, &particles)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetParticleID returns the id of the particle for the given protocol,
+// or false if the particle doesn't exist in that version.
+// Particles that were removed have an id of -1 from the version they were removed in.
+func GetParticleID(particle string, protocol protocol.Protocol) (int, bool) {
+	lastProto := 0
+	lastID := 0
+	found := false
+	for proto, id := range particles[particle] {
+		if proto == int(protocol) {
+			return id, id >= 0
+		}
+
+		if int(protocol) > proto && proto > lastProto {
+			lastProto = proto
+			lastID = id
+			found = true
+		}
+	}
+	return lastID, found && lastID >= 0
+}
@@ -0,0 +1,32 @@
+package particles
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"testing"
+)
+
+func TestGetParticleID(t *testing.T) {
+	tests := []struct {
+		particle string
+		proto    protocol.Protocol
+		id       int
+		ok       bool
+	}{
+		{"minecraft:flame", protocol.V1_8, 26, true},
+		{"minecraft:flame", protocol.V1_16_4, 26, true},
+		{"minecraft:flame", protocol.V1_17, 28, true},
+		{"minecraft:flame", protocol.V1_18, 27, true},
+		{"minecraft:flame", protocol.V1_20_2, 28, true},
+		{"minecraft:barrier", protocol.V1_17_1, 2, true},
+		{"minecraft:barrier", protocol.V1_18, 0, false},
+		{"minecraft:glow", protocol.V1_16_4, 0, false},
+		{"minecraft:block_marker", protocol.V1_18_2, 3, true},
+		{"minecraft:unknown", protocol.V1_20_2, 0, false},
+	}
+	for _, test := range tests {
+		id, ok := GetParticleID(test.particle, test.proto)
+		if ok != test.ok || (ok && id != test.id) {
+			t.Errorf("GetParticleID(%q, %d) = %d, %v, want %d, %v", test.particle, test.proto, id, ok, test.id, test.ok)
+		}
+	}
+}
@@ -19,6 +19,7 @@ const (
 	Status
 	Login
 	Play
+	Configuration
 )
 
 func (state State) String() string {
@@ -31,6 +32,8 @@ func (state State) String() string {
 		return "Login"
 	case Play:
 		return "Play"
+	case Configuration:
+		return "Configuration"
 	default:
 		return "Unknown"
 	}
@@ -81,6 +84,16 @@ const (
 	V1_16_2 Protocol = 751
 	V1_16_3 Protocol = 753
 	V1_16_4 Protocol = 754
+	V1_17   Protocol = 755
+	V1_17_1 Protocol = 756
+	V1_18   Protocol = 757
+	V1_18_2 Protocol = 758
+	V1_19   Protocol = 759
+	V1_19_1 Protocol = 760
+	V1_19_3 Protocol = 761
+	V1_19_4 Protocol = 762
+	V1_20   Protocol = 763
+	V1_20_2 Protocol = 764
 )
 
 var (
@@ -94,6 +107,10 @@ var (
 		V1_14, V1_14_1, V1_14_2, V1_14_3, V1_14_4,
 		V1_15, V1_15_1, V1_15_2,
 		V1_16, V1_16_1, V1_16_2, V1_16_3, V1_16_4,
+		V1_17, V1_17_1,
+		V1_18, V1_18_2,
+		V1_19, V1_19_1, V1_19_3, V1_19_4,
+		V1_20, V1_20_2,
 	}
 	LowestProtocol  = SupportedProtocols[0]
 	HighestProtocol = SupportedProtocols[len(SupportedProtocols)-1]
@@ -0,0 +1,179 @@
+package protocol
+
+import "github.com/r4g3baby/mcserver/pkg/util/nbt"
+
+type (
+	// ChatType decorates the chat messages of a given type, sent to 1.19+ clients
+	ChatType struct {
+		ID   int32
+		Name string
+		// Chat and Narration are left empty for messages that are shown as they are
+		Chat      ChatDecoration
+		Narration ChatDecoration
+		// Overlay and NarrationPriority are only used by 1.19, which still sent system messages through chat types
+		Overlay           bool
+		NarrationPriority string
+	}
+
+	ChatDecoration struct {
+		TranslationKey string
+		Parameters     []string
+	}
+
+	// DamageType describes a source of damage, which 1.19.4+ clients need to know about to join
+	DamageType struct {
+		Name             string
+		MessageID        string
+		Scaling          string
+		Exhaustion       float32
+		Effects          string
+		DeathMessageType string
+		Since            Protocol
+	}
+)
+
+var (
+	DefaultChatTypes = []ChatType{{
+		ID:   0,
+		Name: "minecraft:chat",
+		Chat: ChatDecoration{
+			TranslationKey: "chat.type.text",
+			Parameters:     []string{"sender", "content"},
+		},
+		Narration: ChatDecoration{
+			TranslationKey: "chat.type.text.narrate",
+			Parameters:     []string{"sender", "content"},
+		},
+		NarrationPriority: "chat",
+	}, {
+		ID:                1,
+		Name:              "minecraft:system",
+		NarrationPriority: "system",
+	}, {
+		ID:      2,
+		Name:    "minecraft:game_info",
+		Overlay: true,
+	}}
+
+	DefaultDamageTypes = []DamageType{
+		{Name: "minecraft:arrow", MessageID: "arrow", Exhaustion: 0.1},
+		{Name: "minecraft:bad_respawn_point", MessageID: "badRespawnPoint", Scaling: "always", Exhaustion: 0.1, DeathMessageType: "intentional_game_design"},
+		{Name: "minecraft:cactus", MessageID: "cactus", Exhaustion: 0.1},
+		{Name: "minecraft:cramming", MessageID: "cramming"},
+		{Name: "minecraft:dragon_breath", MessageID: "dragonBreath"},
+		{Name: "minecraft:drown", MessageID: "drown", Effects: "drowning"},
+		{Name: "minecraft:dry_out", MessageID: "dryout", Exhaustion: 0.1},
+		{Name: "minecraft:explosion", MessageID: "explosion", Scaling: "always", Exhaustion: 0.1},
+		{Name: "minecraft:fall", MessageID: "fall", DeathMessageType: "fall_variants"},
+		{Name: "minecraft:falling_anvil", MessageID: "anvil", Exhaustion: 0.1},
+		{Name: "minecraft:falling_block", MessageID: "fallingBlock", Exhaustion: 0.1},
+		{Name: "minecraft:falling_stalactite", MessageID: "fallingStalactite", Exhaustion: 0.1},
+		{Name: "minecraft:fireball", MessageID: "fireball", Exhaustion: 0.1, Effects: "burning"},
+		{Name: "minecraft:fireworks", MessageID: "fireworks", Exhaustion: 0.1},
+		{Name: "minecraft:fly_into_wall", MessageID: "flyIntoWall"},
+		{Name: "minecraft:freeze", MessageID: "freeze", Effects: "freezing"},
+		{Name: "minecraft:generic", MessageID: "generic"},
+		{Name: "minecraft:generic_kill", MessageID: "genericKill", Since: V1_20},
+		{Name: "minecraft:hot_floor", MessageID: "hotFloor", Exhaustion: 0.1, Effects: "burning"},
+		{Name: "minecraft:in_fire", MessageID: "inFire", Exhaustion: 0.1, Effects: "burning"},
+		{Name: "minecraft:in_wall", MessageID: "inWall"},
+		{Name: "minecraft:indirect_magic", MessageID: "indirectMagic"},
+		{Name: "minecraft:lava", MessageID: "lava", Exhaustion: 0.1, Effects: "burning"},
+		{Name: "minecraft:lightning_bolt", MessageID: "lightningBolt", Exhaustion: 0.1},
+		{Name: "minecraft:magic", MessageID: "magic"},
+		{Name: "minecraft:mob_attack", MessageID: "mob", Exhaustion: 0.1},
+		{Name: "minecraft:mob_attack_no_aggro", MessageID: "mob", Exhaustion: 0.1},
+		{Name: "minecraft:mob_projectile", MessageID: "mob", Exhaustion: 0.1},
+		{Name: "minecraft:on_fire", MessageID: "onFire", Effects: "burning"},
+		{Name: "minecraft:out_of_world", MessageID: "outOfWorld"},
+		{Name: "minecraft:outside_border", MessageID: "outsideBorder", Since: V1_20},
+		{Name: "minecraft:player_attack", MessageID: "player", Exhaustion: 0.1},
+		{Name: "minecraft:player_explosion", MessageID: "explosion.player", Scaling: "always", Exhaustion: 0.1},
+		{Name: "minecraft:sonic_boom", MessageID: "sonic_boom", Scaling: "always"},
+		{Name: "minecraft:stalagmite", MessageID: "stalagmite"},
+		{Name: "minecraft:starve", MessageID: "starve"},
+		{Name: "minecraft:sting", MessageID: "sting", Exhaustion: 0.1},
+		{Name: "minecraft:sweet_berry_bush", MessageID: "sweetBerryBush", Exhaustion: 0.1, Effects: "poking"},
+		{Name: "minecraft:thorns", MessageID: "thorns", Exhaustion: 0.1, Effects: "thorns"},
+		{Name: "minecraft:thrown", MessageID: "thrown", Exhaustion: 0.1},
+		{Name: "minecraft:trident", MessageID: "trident", Exhaustion: 0.1},
+		{Name: "minecraft:unattributed_fireball", MessageID: "onFire", Exhaustion: 0.1, Effects: "burning"},
+		{Name: "minecraft:wither", MessageID: "wither"},
+		{Name: "minecraft:wither_skull", MessageID: "witherSkull", Exhaustion: 0.1},
+	}
+)
+
+// ToCompound returns the chat type for the given protocol, 1.19.1 dropped the types that
+// only system messages used, so those are skipped by returning false
+func (chatType ChatType) ToCompound(proto Protocol) (nbt.CompoundTag, bool) {
+	if proto >= V1_19_1 {
+		if chatType.Chat.TranslationKey == "" {
+			return nil, false
+		}
+
+		return nbt.CompoundTag{
+			"chat":      chatType.Chat.toCompound(),
+			"narration": chatType.Narration.toCompound(),
+		}, true
+	}
+
+	compound := nbt.CompoundTag{}
+	if chatType.Overlay {
+		compound["overlay"] = nbt.CompoundTag{}
+	} else if chatType.Chat.TranslationKey != "" {
+		compound["chat"] = nbt.CompoundTag{"decoration": chatType.Chat.toDecoration()}
+	} else {
+		compound["chat"] = nbt.CompoundTag{}
+	}
+
+	if chatType.NarrationPriority != "" {
+		narration := nbt.CompoundTag{"priority": nbt.StringTag(chatType.NarrationPriority)}
+		if chatType.Narration.TranslationKey != "" {
+			narration["decoration"] = chatType.Narration.toDecoration()
+		}
+		compound["narration"] = narration
+	}
+
+	return compound, true
+}
+
+func (decoration ChatDecoration) toCompound() nbt.CompoundTag {
+	var parameters nbt.ListTag
+	for _, parameter := range decoration.Parameters {
+		parameters = append(parameters, nbt.StringTag(parameter))
+	}
+
+	return nbt.CompoundTag{
+		"translation_key": nbt.StringTag(decoration.TranslationKey),
+		"parameters":      parameters,
+	}
+}
+
+func (decoration ChatDecoration) toDecoration() nbt.CompoundTag {
+	compound := decoration.toCompound()
+	compound["style"] = nbt.CompoundTag{}
+	return compound
+}
+
+func (damageType DamageType) ToCompound() nbt.CompoundTag {
+	scaling := damageType.Scaling
+	if scaling == "" {
+		scaling = "when_caused_by_living_non_player"
+	}
+
+	compound := nbt.CompoundTag{
+		"message_id": nbt.StringTag(damageType.MessageID),
+		"scaling":    nbt.StringTag(scaling),
+		"exhaustion": nbt.FloatTag(damageType.Exhaustion),
+	}
+
+	if damageType.Effects != "" {
+		compound["effects"] = nbt.StringTag(damageType.Effects)
+	}
+
+	if damageType.DeathMessageType != "" {
+		compound["death_message_type"] = nbt.StringTag(damageType.DeathMessageType)
+	}
+
+	return compound
+}
@@ -0,0 +1,54 @@
+package sounds
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+)
+
+type Category int32
+
+const (
+	MasterCategory Category = iota
+	MusicCategory
+	RecordsCategory
+	WeatherCategory
+	BlocksCategory
+	HostileCategory
+	NeutralCategory
+	PlayersCategory
+	AmbientCategory
+	VoiceCategory
+)
+
+var (
+	//go:embed sounds.json
+	soundsFile []byte
+
+	sounds map[string]map[int]string
+)
+
+func init() {
+	err := json.Unmarshal(soundsFile, &sounds)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetSoundName returns the name the given protocol knows the sound by.
+// Sounds missing from the registry, like the ones added by resource packs, are returned unchanged.
+func GetSoundName(sound string, protocol protocol.Protocol) string {
+	lastProto := 0
+	lastName := sound
+	for proto, name := range sounds[sound] {
+		if proto == int(protocol) {
+			return name
+		}
+
+		if int(protocol) > proto && proto > lastProto {
+			lastProto = proto
+			lastName = name
+		}
+	}
+	return lastName
+}
@@ -0,0 +1,425 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Request bodies are small json documents, anything bigger is rejected
+const apiMaxBodySize = 64 * 1024
+
+var ErrAPINoToken = errors.New("api token is not set")
+
+type (
+	apiServer struct {
+		server   Server
+		listener net.Listener
+		http     *http.Server
+		token    string
+	}
+
+	// apiSource collects every message sent to it as the command response
+	apiSource struct {
+		mutex  sync.Mutex
+		output []string
+	}
+
+	apiPlayer struct {
+		Name     string    `json:"name"`
+		UniqueID uuid.UUID `json:"uuid"`
+		Latency  int64     `json:"latency"`
+		Protocol int32     `json:"protocol"`
+		State    string    `json:"state"`
+	}
+
+	apiBan struct {
+		Target  string     `json:"target"`
+		Name    string     `json:"name,omitempty"`
+		Created time.Time  `json:"created"`
+		Source  string     `json:"source"`
+		Expires *time.Time `json:"expires,omitempty"`
+		Reason  string     `json:"reason"`
+	}
+
+	apiError struct {
+		Error string `json:"error"`
+	}
+)
+
+func (api *apiServer) serve() {
+	if err := api.http.Serve(api.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Log.Error(err, "error occurred while serving api")
+	}
+}
+
+func (api *apiServer) Close() error {
+	return api.http.Close()
+}
+
+func (api *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/players", api.handlePlayers)
+	mux.HandleFunc("/api/players/", api.handlePlayer)
+	mux.HandleFunc("/api/commands", api.handleCommand)
+	mux.HandleFunc("/api/config", api.handleConfig)
+	mux.HandleFunc("/api/world/save", api.handleWorldSave)
+	mux.HandleFunc("/api/whitelist", api.handleWhitelist)
+	mux.HandleFunc("/api/whitelist/", api.handleWhitelist)
+	mux.HandleFunc("/api/bans", api.handleBans(false))
+	mux.HandleFunc("/api/bans/", api.handleBans(false))
+	mux.HandleFunc("/api/ip-bans", api.handleBans(true))
+	mux.HandleFunc("/api/ip-bans/", api.handleBans(true))
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(api.token)) != 1 {
+			writeAPIError(writer, http.StatusUnauthorized, "invalid api token")
+			return
+		}
+
+		request.Body = http.MaxBytesReader(writer, request.Body, apiMaxBodySize)
+		mux.ServeHTTP(writer, request)
+	})
+}
+
+func (api *apiServer) handlePlayers(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	players := make([]apiPlayer, 0)
+	for _, player := range api.server.GetPlayers() {
+		players = append(players, newAPIPlayer(player))
+	}
+	writeAPIResponse(writer, http.StatusOK, players)
+}
+
+// handlePlayer serves /api/players/{name or uuid}[/kick|/message]
+func (api *apiServer) handlePlayer(writer http.ResponseWriter, request *http.Request) {
+	path := strings.Split(strings.TrimPrefix(request.URL.Path, "/api/players/"), "/")
+	if len(path) > 2 {
+		writeAPIError(writer, http.StatusNotFound, "not found")
+		return
+	}
+
+	var player Player
+	if uniqueID, err := uuid.Parse(path[0]); err == nil {
+		player = api.server.GetPlayer(uniqueID)
+	} else {
+		player = api.server.GetPlayerByName(path[0])
+	}
+
+	if player == nil {
+		writeAPIError(writer, http.StatusNotFound, ErrPlayerNotFound.Error())
+		return
+	}
+
+	if len(path) == 1 {
+		if request.Method != http.MethodGet {
+			writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		writeAPIResponse(writer, http.StatusOK, newAPIPlayer(player))
+		return
+	}
+
+	if request.Method != http.MethodPost {
+		writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var body struct {
+		Reason  json.RawMessage `json:"reason"`
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		writeAPIError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var err error
+	switch path[1] {
+	case "kick":
+		var reason []chat.Component
+		if len(body.Reason) > 0 {
+			if reason, err = chat.FromJSON(body.Reason); err != nil {
+				writeAPIError(writer, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		err = player.Kick(reason)
+	case "message":
+		var message []chat.Component
+		if message, err = chat.FromJSON(body.Message); err != nil {
+			writeAPIError(writer, http.StatusBadRequest, err.Error())
+			return
+		}
+		err = player.SendMessage(message)
+	default:
+		writeAPIError(writer, http.StatusNotFound, "not found")
+		return
+	}
+
+	if err != nil {
+		writeAPIError(writer, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (api *apiServer) handleCommand(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var body struct {
+		Command string `json:"command"`
+	}
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		writeAPIError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log.Log.WithValues(
+		"connection", request.RemoteAddr,
+		"command", body.Command,
+	).Info("api issued server command")
+
+	source := &apiSource{}
+	api.server.DispatchCommand(source, strings.TrimPrefix(body.Command, "/"))
+	writeAPIResponse(writer, http.StatusOK, struct {
+		Output []string `json:"output"`
+	}{source.getOutput()})
+}
+
+func (api *apiServer) handleConfig(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// Secrets are never handed out, even to authenticated clients
+	config := api.server.GetConfig()
+	config.RCon.Password = ""
+	config.API.Token = ""
+	writeAPIResponse(writer, http.StatusOK, config)
+}
+
+func (api *apiServer) handleWorldSave(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if err := api.server.SaveWorld(); err != nil {
+		writeAPIError(writer, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// handleWhitelist serves /api/whitelist and /api/whitelist/{name}
+func (api *apiServer) handleWhitelist(writer http.ResponseWriter, request *http.Request) {
+	whitelist := api.server.GetWhitelist()
+	if name := strings.TrimPrefix(request.URL.Path, "/api/whitelist/"); name != request.URL.Path {
+		if request.Method != http.MethodDelete {
+			writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		if err := whitelist.Remove(offlineUniqueID(name)); err != nil {
+			writeAPIError(writer, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		writeAPIResponse(writer, http.StatusOK, struct {
+			Enabled bool             `json:"enabled"`
+			Entries []WhitelistEntry `json:"entries"`
+		}{whitelist.IsEnabled(), whitelist.GetEntries()})
+	case http.MethodPost:
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil || body.Name == "" {
+			writeAPIError(writer, http.StatusBadRequest, "missing player name")
+			return
+		}
+
+		if err := whitelist.Add(WhitelistEntry{UniqueID: offlineUniqueID(body.Name), Name: body.Name}); err != nil {
+			writeAPIError(writer, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writer.WriteHeader(http.StatusNoContent)
+	default:
+		writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// handleBans serves /api/bans and /api/bans/{name} or their ip counterparts
+func (api *apiServer) handleBans(ip bool) http.HandlerFunc {
+	prefix, list := "/api/bans/", api.server.GetBanList()
+	if ip {
+		prefix, list = "/api/ip-bans/", api.server.GetIPBanList()
+	}
+
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if target := strings.TrimPrefix(request.URL.Path, prefix); target != request.URL.Path {
+			if request.Method != http.MethodDelete {
+				writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+				return
+			}
+
+			if !ip {
+				target = offlineUniqueID(target).String()
+			}
+			if err := list.Remove(target); err != nil {
+				writeAPIError(writer, http.StatusInternalServerError, err.Error())
+				return
+			}
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch request.Method {
+		case http.MethodGet:
+			bans := make([]apiBan, 0)
+			for _, entry := range list.GetEntries() {
+				bans = append(bans, newAPIBan(entry))
+			}
+			writeAPIResponse(writer, http.StatusOK, bans)
+		case http.MethodPost:
+			var body struct {
+				Name    string    `json:"name"`
+				IP      string    `json:"ip"`
+				Reason  string    `json:"reason"`
+				Expires time.Time `json:"expires"`
+			}
+			if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+				writeAPIError(writer, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			var entry BanEntry
+			var err error
+			if ip {
+				if net.ParseIP(body.IP) == nil {
+					writeAPIError(writer, http.StatusBadRequest, ErrInvalidIP.Error())
+					return
+				}
+				entry, err = BanIP(api.server, body.IP, "Api", body.Reason, body.Expires)
+			} else {
+				if body.Name == "" {
+					writeAPIError(writer, http.StatusBadRequest, "missing player name")
+					return
+				}
+				entry, err = BanPlayer(api.server, body.Name, "Api", body.Reason, body.Expires)
+			}
+
+			if err != nil {
+				writeAPIError(writer, http.StatusInternalServerError, err.Error())
+				return
+			}
+			writeAPIResponse(writer, http.StatusCreated, newAPIBan(entry))
+		default:
+			writeAPIError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	}
+}
+
+func (source *apiSource) GetName() string {
+	return "Api"
+}
+
+func (source *apiSource) SendMessage(message []chat.Component) error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	source.output = append(source.output, chat.ToLegacyText(message))
+	return nil
+}
+
+func (source *apiSource) HasPermission(_ string) bool {
+	return true
+}
+
+func (source *apiSource) IsOperator() bool {
+	return true
+}
+
+func (source *apiSource) getOutput() []string {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return append([]string{}, source.output...)
+}
+
+func newAPIPlayer(player Player) apiPlayer {
+	return apiPlayer{
+		Name:     player.GetUsername(),
+		UniqueID: player.GetUniqueID(),
+		Latency:  player.GetLatency().Milliseconds(),
+		Protocol: int32(player.GetProtocol()),
+		State:    player.GetState().String(),
+	}
+}
+
+func newAPIBan(entry BanEntry) apiBan {
+	ban := apiBan{
+		Target:  entry.Target,
+		Name:    entry.Name,
+		Created: entry.Created,
+		Source:  entry.Source,
+		Reason:  entry.Reason,
+	}
+	if !entry.Expires.IsZero() {
+		ban.Expires = &entry.Expires
+	}
+	return ban
+}
+
+func writeAPIResponse(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		log.Log.Error(err, "failed to write api response")
+	}
+}
+
+func writeAPIError(writer http.ResponseWriter, status int, message string) {
+	writeAPIResponse(writer, status, apiError{Error: message})
+}
+
+func listenAPI(server Server, config APIConf) (*apiServer, error) {
+	if config.Token == "" {
+		return nil, ErrAPINoToken
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	api := &apiServer{
+		server:   server,
+		listener: listener,
+		token:    config.Token,
+	}
+	api.http = &http.Server{Handler: api.handler()}
+	return api, nil
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"sync"
+)
+
+type (
+	BossBar interface {
+		GetUniqueID() uuid.UUID
+		SetTitle(title []chat.Component)
+		GetTitle() []chat.Component
+		SetProgress(progress float32)
+		GetProgress() float32
+		SetColor(color packets.BossBarColor)
+		GetColor() packets.BossBarColor
+		SetOverlay(overlay packets.BossBarOverlay)
+		GetOverlay() packets.BossBarOverlay
+		SetFlags(flags packets.BossBarFlags)
+		GetFlags() packets.BossBarFlags
+		AddPlayer(player Player) error
+		RemovePlayer(player Player) error
+		RemoveAll()
+		GetPlayers() []Player
+	}
+
+	bossBar struct {
+		uniqueID uuid.UUID
+
+		mutex    sync.RWMutex
+		title    []chat.Component
+		progress float32
+		color    packets.BossBarColor
+		overlay  packets.BossBarOverlay
+		flags    packets.BossBarFlags
+		players  map[uuid.UUID]Player
+	}
+)
+
+func (bar *bossBar) GetUniqueID() uuid.UUID {
+	return bar.uniqueID
+}
+
+func (bar *bossBar) SetTitle(title []chat.Component) {
+	bar.mutex.Lock()
+	bar.title = title
+	bar.mutex.Unlock()
+
+	bar.broadcast(&packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.UpdateTitleBossBarAction,
+		Title:    title,
+	})
+}
+
+func (bar *bossBar) GetTitle() []chat.Component {
+	bar.mutex.RLock()
+	defer bar.mutex.RUnlock()
+	return bar.title
+}
+
+func (bar *bossBar) SetProgress(progress float32) {
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+
+	bar.mutex.Lock()
+	bar.progress = progress
+	bar.mutex.Unlock()
+
+	bar.broadcast(&packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.UpdateHealthBossBarAction,
+		Health:   progress,
+	})
+}
+
+func (bar *bossBar) GetProgress() float32 {
+	bar.mutex.RLock()
+	defer bar.mutex.RUnlock()
+	return bar.progress
+}
+
+func (bar *bossBar) SetColor(color packets.BossBarColor) {
+	bar.mutex.Lock()
+	bar.color = color
+	overlay := bar.overlay
+	bar.mutex.Unlock()
+
+	bar.broadcast(&packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.UpdateStyleBossBarAction,
+		Color:    color,
+		Overlay:  overlay,
+	})
+}
+
+func (bar *bossBar) GetColor() packets.BossBarColor {
+	bar.mutex.RLock()
+	defer bar.mutex.RUnlock()
+	return bar.color
+}
+
+func (bar *bossBar) SetOverlay(overlay packets.BossBarOverlay) {
+	bar.mutex.Lock()
+	bar.overlay = overlay
+	color := bar.color
+	bar.mutex.Unlock()
+
+	bar.broadcast(&packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.UpdateStyleBossBarAction,
+		Color:    color,
+		Overlay:  overlay,
+	})
+}
+
+func (bar *bossBar) GetOverlay() packets.BossBarOverlay {
+	bar.mutex.RLock()
+	defer bar.mutex.RUnlock()
+	return bar.overlay
+}
+
+func (bar *bossBar) SetFlags(flags packets.BossBarFlags) {
+	bar.mutex.Lock()
+	bar.flags = flags
+	bar.mutex.Unlock()
+
+	bar.broadcast(&packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.UpdateFlagsBossBarAction,
+		Flags:    flags,
+	})
+}
+
+func (bar *bossBar) GetFlags() packets.BossBarFlags {
+	bar.mutex.RLock()
+	defer bar.mutex.RUnlock()
+	return bar.flags
+}
+
+func (bar *bossBar) AddPlayer(player Player) error {
+	bar.mutex.Lock()
+	if _, ok := bar.players[player.GetUniqueID()]; ok {
+		bar.mutex.Unlock()
+		return nil
+	}
+	bar.players[player.GetUniqueID()] = player
+	packet := &packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.AddBossBarAction,
+		Title:    bar.title,
+		Health:   bar.progress,
+		Color:    bar.color,
+		Overlay:  bar.overlay,
+		Flags:    bar.flags,
+	}
+	bar.mutex.Unlock()
+
+	return bar.sendPacket(player, packet)
+}
+
+func (bar *bossBar) RemovePlayer(player Player) error {
+	bar.mutex.Lock()
+	if _, ok := bar.players[player.GetUniqueID()]; !ok {
+		bar.mutex.Unlock()
+		return nil
+	}
+	delete(bar.players, player.GetUniqueID())
+	bar.mutex.Unlock()
+
+	return bar.sendPacket(player, &packets.PacketPlayOutBossBar{
+		UniqueID: bar.uniqueID,
+		Action:   packets.RemoveBossBarAction,
+	})
+}
+
+func (bar *bossBar) RemoveAll() {
+	for _, player := range bar.GetPlayers() {
+		if err := bar.RemovePlayer(player); err != nil {
+			log.Log.WithValues(
+				"name", player.GetUsername(),
+				"uuid", player.GetUniqueID(),
+			).Error(err, "failed to remove boss bar")
+		}
+	}
+}
+
+func (bar *bossBar) GetPlayers() []Player {
+	bar.mutex.RLock()
+	defer bar.mutex.RUnlock()
+
+	var players []Player
+	for _, player := range bar.players {
+		players = append(players, player)
+	}
+	return players
+}
+
+func (bar *bossBar) broadcast(packet *packets.PacketPlayOutBossBar) {
+	for _, player := range bar.GetPlayers() {
+		// Players that left the server are forgotten instead of written to
+		if player.GetServer().GetPlayer(player.GetUniqueID()) != player {
+			bar.mutex.Lock()
+			delete(bar.players, player.GetUniqueID())
+			bar.mutex.Unlock()
+			continue
+		}
+
+		if err := bar.sendPacket(player, packet); err != nil {
+			log.Log.WithValues(
+				"name", player.GetUsername(),
+				"uuid", player.GetUniqueID(),
+			).Error(err, "failed to update boss bar")
+		}
+	}
+}
+
+func (bar *bossBar) sendPacket(player Player, packet *packets.PacketPlayOutBossBar) error {
+	// Boss bars were only added in 1.9, older clients simply don't see them
+	if player.GetProtocol() < protocol.V1_9 {
+		return nil
+	}
+	return player.SendPacket(packet)
+}
+
+func NewBossBar(title []chat.Component, color packets.BossBarColor, overlay packets.BossBarOverlay) BossBar {
+	return &bossBar{
+		uniqueID: uuid.New(),
+		title:    title,
+		progress: 1,
+		color:    color,
+		overlay:  overlay,
+		players:  make(map[uuid.UUID]Player),
+	}
+}
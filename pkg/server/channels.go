@@ -0,0 +1,165 @@
+package server
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"sort"
+	"strings"
+)
+
+const (
+	// ServerBrand is shown by clients in their debug screen
+	ServerBrand = "mcserver"
+
+	// MaxPlayerChannels is how many channels a single player can register, the same limit Bukkit uses
+	MaxPlayerChannels = 128
+)
+
+var (
+	ErrChannelRegistered = errors.New("plugin channel already registered")
+	ErrInvalidChannel    = errors.New("plugin channel name must be a namespaced identifier")
+)
+
+// ChannelHandler is called with the data of every plugin message a player sends on the channel
+type ChannelHandler func(player Player, data []byte)
+
+// RegisterChannel listens for plugin messages on the namespaced channel,
+// players are told about it so their client mods know the server uses it
+func (server *server) RegisterChannel(name string, handler ChannelHandler) error {
+	if !isValidChannel(name) {
+		return ErrInvalidChannel
+	}
+
+	if isBuiltinChannel(name) {
+		return ErrChannelRegistered
+	}
+
+	if _, loaded := server.channels.LoadOrStore(name, handler); loaded {
+		return ErrChannelRegistered
+	}
+
+	server.announceChannels(packets.RegisterChannel, name)
+	return nil
+}
+
+func (server *server) UnregisterChannel(name string) {
+	if _, loaded := server.channels.LoadAndDelete(name); loaded {
+		server.announceChannels(packets.UnregisterChannel, name)
+	}
+}
+
+func (server *server) GetChannels() []string {
+	var channels []string
+	server.channels.Range(func(key, _ interface{}) bool {
+		channels = append(channels, key.(string))
+		return true
+	})
+	sort.Strings(channels)
+	return channels
+}
+
+func (server *server) getChannelHandler(name string) ChannelHandler {
+	if value, ok := server.channels.Load(name); ok {
+		return value.(ChannelHandler)
+	}
+	return nil
+}
+
+// announceChannels sends a register or unregister message to every player that can receive it
+func (server *server) announceChannels(channel string, names ...string) {
+	server.ForEachPlayer(func(player Player) bool {
+		if state := player.GetState(); state == protocol.Play || state == protocol.Configuration {
+			if err := player.SendPluginMessage(channel, writeChannels(player.GetProtocol(), names)); err != nil {
+				log.Log.WithValues(
+					"name", player.GetUsername(),
+					"uuid", player.GetUniqueID(),
+				).Error(err, "failed to announce plugin channels")
+			}
+		}
+		return true
+	})
+}
+
+// sendServerChannels tells the client our brand and the channels we listen on
+func sendServerChannels(player Player) error {
+	brand := bytes.NewBuffer(nil)
+	if err := brand.WriteUtf(ServerBrand, 32767); err != nil {
+		return err
+	}
+
+	if err := player.SendPluginMessage(packets.BrandChannel, brand.Bytes()); err != nil {
+		return err
+	}
+
+	if channels := player.GetServer().GetChannels(); len(channels) > 0 {
+		return player.SendPluginMessage(packets.RegisterChannel, writeChannels(player.GetProtocol(), channels))
+	}
+	return nil
+}
+
+func handlePluginMessage(player Player, channel string, data []byte) {
+	switch channel {
+	case packets.BrandChannel:
+		if brand, err := bytes.NewBuffer(data).ReadUtf(32767); err == nil {
+			player.setClientBrand(brand)
+		}
+	case packets.RegisterChannel:
+		player.registerChannels(readChannels(player.GetProtocol(), data))
+	case packets.UnregisterChannel:
+		player.unregisterChannels(readChannels(player.GetProtocol(), data))
+	default:
+		if handler := player.GetServer().getChannelHandler(channel); handler != nil {
+			handler(player, data)
+		} else if debugLog := log.Log.V(1); debugLog.Enabled() {
+			debugLog.WithValues(
+				"name", player.GetUsername(),
+				"channel", channel,
+			).Info("received plugin message on unknown channel")
+		}
+	}
+}
+
+// readChannels parses the null separated channel names of a register or unregister message
+func readChannels(proto protocol.Protocol, data []byte) []string {
+	var channels []string
+	for _, channel := range strings.Split(string(data), "\x00") {
+		if channel != "" {
+			channels = append(channels, packets.FromLegacyChannel(channel, proto))
+		}
+	}
+	return channels
+}
+
+func writeChannels(proto protocol.Protocol, channels []string) []byte {
+	names := make([]string, len(channels))
+	for i, channel := range channels {
+		names[i] = packets.ToLegacyChannel(channel, proto)
+	}
+	return []byte(strings.Join(names, "\x00"))
+}
+
+func isBuiltinChannel(name string) bool {
+	return name == packets.BrandChannel || name == packets.RegisterChannel || name == packets.UnregisterChannel
+}
+
+// isValidChannel checks the name is a namespace and path made of the characters identifiers allow
+func isValidChannel(name string) bool {
+	separator := strings.IndexByte(name, ':')
+	if separator <= 0 || separator == len(name)-1 {
+		return false
+	}
+
+	for i, char := range name {
+		switch {
+		case char >= 'a' && char <= 'z', char >= '0' && char <= '9', char == '_', char == '-', char == '.':
+		case char == '/' && i > separator:
+		case i == separator:
+		default:
+			return false
+		}
+	}
+	return true
+}
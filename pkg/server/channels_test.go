@@ -0,0 +1,66 @@
+package server
+
+import (
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestIsValidChannel(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"example:main", true},
+		{"my_mod:sync/state-1.0", true},
+		{"BungeeCord", false},
+		{"example:", false},
+		{":main", false},
+		{"Example:main", false},
+		{"example/nested:main", false},
+		{"example:main:extra", false},
+	}
+	for _, test := range tests {
+		if got := isValidChannel(test.name); got != test.want {
+			t.Errorf("isValidChannel(%q) = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestChannels(t *testing.T) {
+	channels := []string{"minecraft:brand", "bungeecord:main", "example:main"}
+	tests := []struct {
+		proto protocol.Protocol
+		data  string
+	}{
+		{protocol.V1_12_2, "MC|Brand\x00BungeeCord\x00example:main"},
+		{protocol.V1_13, "minecraft:brand\x00bungeecord:main\x00example:main"},
+	}
+	for _, test := range tests {
+		if got := string(writeChannels(test.proto, channels)); got != test.data {
+			t.Errorf("writeChannels(%d) = %q, want %q", test.proto, got, test.data)
+		}
+
+		if got := readChannels(test.proto, []byte(test.data+"\x00")); !reflect.DeepEqual(got, channels) {
+			t.Errorf("readChannels(%d) = %v, want %v", test.proto, got, channels)
+		}
+	}
+}
+
+func TestRegisterChannels(t *testing.T) {
+	player := &player{channels: make(map[string]struct{})}
+	player.registerChannels([]string{"example:main", "FML|HS", "Example:main"})
+	if got := player.GetChannels(); !reflect.DeepEqual(got, []string{"example:main"}) {
+		t.Errorf("GetChannels() = %v, want only the valid channel", got)
+	}
+
+	var channels []string
+	for i := 0; i < MaxPlayerChannels*2; i++ {
+		channels = append(channels, fmt.Sprintf("example:channel_%d", i))
+	}
+	player.registerChannels(channels)
+	if got := len(player.GetChannels()); got != MaxPlayerChannels {
+		t.Errorf("len(GetChannels()) = %d, want %d", got, MaxPlayerChannels)
+	}
+}
@@ -0,0 +1,619 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/command"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/permission"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPlayerNotFound  = errors.New("no player was found")
+	ErrInvalidIP       = errors.New("invalid IP address")
+	ErrInvalidDuration = errors.New("invalid duration")
+)
+
+func registerDefaultCommands(server Server) {
+	kick := func(ctx *command.Context) error {
+		player := server.GetPlayerByName(ctx.GetString("player"))
+		if player == nil {
+			return ErrPlayerNotFound
+		}
+
+		reason := ctx.GetString("reason")
+		if reason == "" {
+			reason = "Kicked by an operator"
+		}
+
+		if err := player.Kick([]chat.Component{&chat.TextComponent{Text: reason}}); err != nil {
+			return err
+		}
+		return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Kicked %s: %s", player.GetUsername(), reason), nil))
+	}
+
+	ban := func(ctx *command.Context) error {
+		var expires time.Time
+		if duration := ctx.GetString("duration"); duration != "" {
+			parsed, err := parseDuration(duration)
+			if err != nil {
+				return err
+			}
+			expires = time.Now().Add(parsed)
+		}
+
+		entry, err := BanPlayer(server, ctx.GetString("player"), ctx.GetSource().GetName(), ctx.GetString("reason"), expires)
+		if err != nil {
+			return err
+		}
+		return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Banned %s: %s", entry.Name, entry.Reason), nil))
+	}
+
+	banIP := func(ctx *command.Context) error {
+		ip := ctx.GetString("target")
+		if player := server.GetPlayerByName(ip); player != nil {
+			host, _, err := net.SplitHostPort(player.GetAddress().String())
+			if err != nil {
+				return err
+			}
+			ip = host
+		} else if net.ParseIP(ip) == nil {
+			return ErrInvalidIP
+		}
+
+		entry, err := BanIP(server, ip, ctx.GetSource().GetName(), ctx.GetString("reason"), time.Time{})
+		if err != nil {
+			return err
+		}
+		return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Banned IP %s: %s", entry.Target, entry.Reason), nil))
+	}
+
+	banList := func(ctx *command.Context) error {
+		var lines []string
+		kind := ctx.GetString("type")
+		if kind != "ips" {
+			for _, entry := range server.GetBanList().GetEntries() {
+				lines = append(lines, fmt.Sprintf("%s was banned by %s: %s", entry.Name, entry.Source, entry.Reason))
+			}
+		}
+		if kind != "players" {
+			for _, entry := range server.GetIPBanList().GetEntries() {
+				lines = append(lines, fmt.Sprintf("%s was banned by %s: %s", entry.Target, entry.Source, entry.Reason))
+			}
+		}
+
+		message := coloredText(fmt.Sprintf("There are %d ban(s):", len(lines)), nil)
+		for _, line := range lines {
+			message = append(message, coloredText("\n"+line, nil)...)
+		}
+		return ctx.GetSource().SendMessage(message)
+	}
+
+	setOperator := func(operator bool) command.Command {
+		return func(ctx *command.Context) error {
+			uniqueID, name := resolvePlayer(server, ctx.GetString("player"))
+			user, err := server.GetPermissions().GetUser(uniqueID)
+			if err != nil {
+				return err
+			}
+
+			user.Name, user.Operator = name, operator
+			if err := server.GetPermissions().SaveUser(user); err != nil {
+				return err
+			}
+			updateCommands(server, uniqueID)
+
+			if operator {
+				return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Made %s a server operator", name), nil))
+			}
+			return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Made %s no longer a server operator", name), nil))
+		}
+	}
+
+	editUser := func(fn func(ctx *command.Context, user *permission.User)) command.Command {
+		return func(ctx *command.Context) error {
+			uniqueID, name := resolvePlayer(server, ctx.GetString("player"))
+			user, err := server.GetPermissions().GetUser(uniqueID)
+			if err != nil {
+				return err
+			}
+
+			user.Name = name
+			fn(ctx, &user)
+			if err := server.GetPermissions().SaveUser(user); err != nil {
+				return err
+			}
+			updateCommands(server, uniqueID)
+			return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Updated permissions of %s", name), nil))
+		}
+	}
+
+	editGroup := func(fn func(ctx *command.Context, group *permission.Group)) command.Command {
+		return func(ctx *command.Context) error {
+			name := strings.ToLower(ctx.GetString("group"))
+			group, ok := server.GetPermissions().GetGroup(name)
+			if !ok {
+				group = permission.Group{Name: name}
+			}
+
+			fn(ctx, &group)
+			if err := server.GetPermissions().SaveGroup(group); err != nil {
+				return err
+			}
+			updateCommands(server, uuid.Nil)
+			return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Updated permissions of group %s", name), nil))
+		}
+	}
+
+	suggestGroups := func(_ *command.Context, _ string) []command.Suggestion {
+		var suggestions []command.Suggestion
+		for _, group := range server.GetPermissions().GetGroups() {
+			suggestions = append(suggestions, command.Suggestion{Text: group.Name})
+		}
+		return suggestions
+	}
+
+	setWhitelist := func(enabled bool) command.Command {
+		return func(ctx *command.Context) error {
+			server.GetWhitelist().SetEnabled(enabled)
+			if enabled {
+				return ctx.GetSource().SendMessage(coloredText("Whitelist is now turned on", nil))
+			}
+			return ctx.GetSource().SendMessage(coloredText("Whitelist is now turned off", nil))
+		}
+	}
+
+	for _, node := range []*command.Node{
+		command.Literal("stop").Permission("mcserver.command.stop").Executes(func(ctx *command.Context) error {
+			if err := ctx.GetSource().SendMessage(coloredText("Stopping the server", nil)); err != nil {
+				return err
+			}
+
+			// Players and rcon run commands from their own connection, which the stop waits on
+			go func() {
+				if err := server.Stop(); err != nil && !errors.Is(err, ErrServerStopped) {
+					log.Log.Error(err, "failed to stop server")
+				}
+			}()
+			return nil
+		}),
+		command.Literal("list").Permission("mcserver.command.list").Executes(func(ctx *command.Context) error {
+			var names []string
+			for _, player := range server.GetPlayers() {
+				names = append(names, player.GetUsername())
+			}
+			return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf(
+				"There are %d players online: %s", len(names), strings.Join(names, ", "),
+			), nil))
+		}),
+		command.Literal("kick").Permission("mcserver.command.kick").Then(
+			command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Executes(kick).Then(
+				command.Argument("reason", command.GreedyString()).Executes(kick),
+			),
+		),
+		command.Literal("ban").Permission("mcserver.command.ban").Then(
+			command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Executes(ban).Then(
+				command.Argument("reason", command.GreedyString()).Executes(ban),
+			),
+		),
+		command.Literal("tempban").Permission("mcserver.command.tempban").Then(
+			command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Then(
+				command.Argument("duration", command.Word()).Executes(ban).Then(
+					command.Argument("reason", command.GreedyString()).Executes(ban),
+				),
+			),
+		),
+		command.Literal("ban-ip").Permission("mcserver.command.ban-ip").Then(
+			command.Argument("target", command.Word()).Suggests(suggestPlayers(server)).Executes(banIP).Then(
+				command.Argument("reason", command.GreedyString()).Executes(banIP),
+			),
+		),
+		command.Literal("pardon").Permission("mcserver.command.pardon").Then(
+			command.Argument("player", command.Word()).Suggests(func(_ *command.Context, _ string) []command.Suggestion {
+				var suggestions []command.Suggestion
+				for _, entry := range server.GetBanList().GetEntries() {
+					suggestions = append(suggestions, command.Suggestion{Text: entry.Name})
+				}
+				return suggestions
+			}).Executes(func(ctx *command.Context) error {
+				name := ctx.GetString("player")
+				if err := server.GetBanList().Remove(offlineUniqueID(name).String()); err != nil {
+					return err
+				}
+				return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Unbanned %s", name), nil))
+			}),
+		),
+		command.Literal("pardon-ip").Permission("mcserver.command.pardon-ip").Then(
+			command.Argument("ip", command.Word()).Suggests(func(_ *command.Context, _ string) []command.Suggestion {
+				var suggestions []command.Suggestion
+				for _, entry := range server.GetIPBanList().GetEntries() {
+					suggestions = append(suggestions, command.Suggestion{Text: entry.Target})
+				}
+				return suggestions
+			}).Executes(func(ctx *command.Context) error {
+				ip := ctx.GetString("ip")
+				if err := server.GetIPBanList().Remove(ip); err != nil {
+					return err
+				}
+				return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Unbanned IP %s", ip), nil))
+			}),
+		),
+		command.Literal("banlist").Permission("mcserver.command.banlist").Executes(banList).Then(
+			command.Argument("type", command.Word()).Suggests(func(_ *command.Context, _ string) []command.Suggestion {
+				return command.Suggestions("players", "ips")
+			}).Executes(banList),
+		),
+		command.Literal("whitelist").Permission("mcserver.command.whitelist").Then(
+			command.Literal("on").Executes(setWhitelist(true)),
+			command.Literal("off").Executes(setWhitelist(false)),
+			command.Literal("list").Executes(func(ctx *command.Context) error {
+				var names []string
+				for _, entry := range server.GetWhitelist().GetEntries() {
+					names = append(names, entry.Name)
+				}
+				return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf(
+					"There are %d whitelisted players: %s", len(names), strings.Join(names, ", "),
+				), nil))
+			}),
+			command.Literal("reload").Executes(func(ctx *command.Context) error {
+				if err := server.GetWhitelist().Reload(); err != nil {
+					return err
+				}
+				return ctx.GetSource().SendMessage(coloredText("Reloaded the whitelist", nil))
+			}),
+			command.Literal("add").Then(
+				command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Executes(func(ctx *command.Context) error {
+					name := ctx.GetString("player")
+					if err := server.GetWhitelist().Add(WhitelistEntry{UniqueID: offlineUniqueID(name), Name: name}); err != nil {
+						return err
+					}
+					return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Added %s to the whitelist", name), nil))
+				}),
+			),
+			command.Literal("remove").Then(
+				command.Argument("player", command.Word()).Suggests(func(_ *command.Context, _ string) []command.Suggestion {
+					var suggestions []command.Suggestion
+					for _, entry := range server.GetWhitelist().GetEntries() {
+						suggestions = append(suggestions, command.Suggestion{Text: entry.Name})
+					}
+					return suggestions
+				}).Executes(func(ctx *command.Context) error {
+					name := ctx.GetString("player")
+					if err := server.GetWhitelist().Remove(offlineUniqueID(name)); err != nil {
+						return err
+					}
+					return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Removed %s from the whitelist", name), nil))
+				}),
+			),
+		),
+		command.Literal("op").Permission("mcserver.command.op").Then(
+			command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Executes(setOperator(true)),
+		),
+		command.Literal("deop").Permission("mcserver.command.deop").Then(
+			command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Executes(setOperator(false)),
+		),
+		command.Literal("permission").Permission("mcserver.command.permission").Then(
+			command.Literal("check").Then(
+				command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Then(
+					command.Argument("node", command.String()).Executes(func(ctx *command.Context) error {
+						uniqueID, name := resolvePlayer(server, ctx.GetString("player"))
+						node := ctx.GetString("node")
+						if server.GetPermissions().HasPermission(uniqueID, node) {
+							return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("%s has %s", name, node), &chat.Green))
+						}
+						return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("%s doesn't have %s", name, node), &chat.Red))
+					}),
+				),
+			),
+			command.Literal("user").Then(
+				command.Argument("player", command.Word()).Suggests(suggestPlayers(server)).Then(
+					command.Literal("set").Then(
+						command.Argument("node", command.String()).Then(
+							command.Argument("value", command.Bool()).Executes(editUser(func(ctx *command.Context, user *permission.User) {
+								if user.Permissions == nil {
+									user.Permissions = make(map[string]bool)
+								}
+								user.Permissions[ctx.GetString("node")] = ctx.GetBool("value")
+							})),
+						),
+					),
+					command.Literal("unset").Then(
+						command.Argument("node", command.String()).Executes(editUser(func(ctx *command.Context, user *permission.User) {
+							delete(user.Permissions, strings.ToLower(ctx.GetString("node")))
+						})),
+					),
+					command.Literal("group").Then(
+						command.Literal("add").Then(
+							command.Argument("group", command.Word()).Suggests(suggestGroups).Executes(editUser(func(ctx *command.Context, user *permission.User) {
+								user.Groups = append(removeString(user.Groups, ctx.GetString("group")), strings.ToLower(ctx.GetString("group")))
+							})),
+						),
+						command.Literal("remove").Then(
+							command.Argument("group", command.Word()).Suggests(suggestGroups).Executes(editUser(func(ctx *command.Context, user *permission.User) {
+								user.Groups = removeString(user.Groups, ctx.GetString("group"))
+							})),
+						),
+					),
+				),
+			),
+			command.Literal("group").Then(
+				command.Argument("group", command.Word()).Suggests(suggestGroups).Then(
+					command.Literal("set").Then(
+						command.Argument("node", command.String()).Then(
+							command.Argument("value", command.Bool()).Executes(editGroup(func(ctx *command.Context, group *permission.Group) {
+								if group.Permissions == nil {
+									group.Permissions = make(map[string]bool)
+								}
+								group.Permissions[ctx.GetString("node")] = ctx.GetBool("value")
+							})),
+						),
+					),
+					command.Literal("unset").Then(
+						command.Argument("node", command.String()).Executes(editGroup(func(ctx *command.Context, group *permission.Group) {
+							delete(group.Permissions, strings.ToLower(ctx.GetString("node")))
+						})),
+					),
+					command.Literal("parent").Then(
+						command.Literal("add").Then(
+							command.Argument("parent", command.Word()).Suggests(suggestGroups).Executes(editGroup(func(ctx *command.Context, group *permission.Group) {
+								group.Parents = append(removeString(group.Parents, ctx.GetString("parent")), strings.ToLower(ctx.GetString("parent")))
+							})),
+						),
+						command.Literal("remove").Then(
+							command.Argument("parent", command.Word()).Suggests(suggestGroups).Executes(editGroup(func(ctx *command.Context, group *permission.Group) {
+								group.Parents = removeString(group.Parents, ctx.GetString("parent"))
+							})),
+						),
+					),
+					command.Literal("default").Then(
+						command.Argument("value", command.Bool()).Executes(editGroup(func(ctx *command.Context, group *permission.Group) {
+							group.Default = ctx.GetBool("value")
+						})),
+					),
+					command.Literal("delete").Executes(func(ctx *command.Context) error {
+						name := ctx.GetString("group")
+						if err := server.GetPermissions().DeleteGroup(name); err != nil {
+							return err
+						}
+						updateCommands(server, uuid.Nil)
+						return ctx.GetSource().SendMessage(coloredText(fmt.Sprintf("Deleted group %s", name), nil))
+					}),
+				),
+			),
+		),
+		command.Literal("say").Permission("mcserver.command.say").Then(
+			command.Argument("message", command.GreedyString()).Executes(func(ctx *command.Context) error {
+				message := []chat.Component{&chat.TextComponent{
+					Text: fmt.Sprintf("[%s] %s", ctx.GetSource().GetName(), ctx.GetString("message")),
+				}}
+
+				log.Log.Info(chat.ToLegacyText(message))
+				server.Broadcast(&packets.PacketPlayOutChatMessage{
+					Message:  message,
+					Position: 1,
+				}, func(player Player) bool {
+					return player.GetState() == protocol.Play
+				})
+				return nil
+			}),
+		),
+		command.Literal("tps").Permission("mcserver.command.tps").Executes(func(ctx *command.Context) error {
+			message := coloredText("TPS from last 1m, 5m, 15m: ", &chat.Gold)
+			oneMinute, fiveMinutes, fifteenMinutes := server.GetTPS()
+			for i, tps := range []float64{oneMinute, fiveMinutes, fifteenMinutes} {
+				color := &chat.Green
+				if tps < 16 {
+					color = &chat.Red
+				} else if tps < 19 {
+					color = &chat.Yellow
+				}
+
+				text := fmt.Sprintf("%.1f", tps)
+				if i < 2 {
+					text += ", "
+				}
+				message = append(message, coloredText(text, color)...)
+			}
+			return ctx.GetSource().SendMessage(message)
+		}),
+	} {
+		if err := server.GetCommandDispatcher().Register(node); err != nil {
+			log.Log.Error(err, "failed to register command")
+		}
+	}
+}
+
+func suggestPlayers(server Server) command.SuggestionProvider {
+	return func(_ *command.Context, _ string) []command.Suggestion {
+		var suggestions []command.Suggestion
+		for _, player := range server.GetPlayers() {
+			suggestions = append(suggestions, command.Suggestion{Text: player.GetUsername()})
+		}
+		return suggestions
+	}
+}
+
+// resolvePlayer returns the uuid and name of the online player with this name, or the offline mode ones
+func resolvePlayer(server Server, name string) (uuid.UUID, string) {
+	if player := server.GetPlayerByName(name); player != nil {
+		return player.GetUniqueID(), player.GetUsername()
+	}
+	return offlineUniqueID(name), name
+}
+
+// updateCommands resends the command tree after permissions change, to every player when uniqueID is nil
+func updateCommands(server Server, uniqueID uuid.UUID) {
+	server.ForEachPlayer(func(player Player) bool {
+		if (uniqueID == uuid.Nil || player.GetUniqueID() == uniqueID) && player.GetState() == protocol.Play {
+			if err := player.UpdateCommands(); err != nil {
+				log.Log.WithValues(
+					"name", player.GetUsername(),
+					"uuid", player.GetUniqueID(),
+				).Error(err, "failed to update commands")
+			}
+		}
+		return true
+	})
+}
+
+func removeString(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if !strings.EqualFold(v, value) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// parseDuration accepts anything time.ParseDuration does plus days and weeks, such as 7d or 2w
+func parseDuration(text string) (time.Duration, error) {
+	multiplier := time.Duration(1)
+	switch {
+	case strings.HasSuffix(text, "d"):
+		multiplier, text = 24*time.Hour, strings.TrimSuffix(text, "d")
+	case strings.HasSuffix(text, "w"):
+		multiplier, text = 7*24*time.Hour, strings.TrimSuffix(text, "w")
+	}
+
+	if multiplier != 1 {
+		value, err := strconv.Atoi(text)
+		if err != nil || value <= 0 {
+			return 0, ErrInvalidDuration
+		}
+		return time.Duration(value) * multiplier, nil
+	}
+
+	duration, err := time.ParseDuration(text)
+	if err != nil || duration <= 0 {
+		return 0, ErrInvalidDuration
+	}
+	return duration, nil
+}
+
+func coloredText(text string, color *chat.Color) []chat.Component {
+	return []chat.Component{&chat.TextComponent{
+		Text: text,
+		BaseComponent: chat.BaseComponent{
+			Color: color,
+		},
+	}}
+}
+
+// newDeclareCommands flattens the part of the command tree the source can use into the packet node list
+func newDeclareCommands(root *command.Node, source command.Source) (*packets.PacketPlayOutDeclareCommands, error) {
+	indexes := map[*command.Node]int32{root: 0}
+	queue := []*command.Node{root}
+	for i := 0; i < len(queue); i++ {
+		for _, child := range queue[i].GetChildren() {
+			if _, ok := indexes[child]; !ok && child.CanUse(source) {
+				indexes[child] = int32(len(queue))
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	packet := &packets.PacketPlayOutDeclareCommands{RootIndex: 0}
+	for i, node := range queue {
+		var commandNode packets.CommandNode
+		for _, child := range node.GetChildren() {
+			if index, ok := indexes[child]; ok {
+				commandNode.Children = append(commandNode.Children, index)
+			}
+		}
+
+		if node.GetCommand() != nil {
+			commandNode.Flags |= packets.ExecutableCommandNodeFlag
+		}
+
+		if i == 0 {
+			commandNode.Flags |= packets.RootCommandNodeType
+		} else if node.IsLiteral() {
+			commandNode.Flags |= packets.LiteralCommandNodeType
+			commandNode.Name = node.GetName()
+		} else {
+			commandNode.Flags |= packets.ArgumentCommandNodeType
+			commandNode.Name = node.GetName()
+			commandNode.Parser = node.GetArgument().GetParser()
+
+			properties := bytes.NewBuffer(nil)
+			if err := node.GetArgument().WriteProperties(properties); err != nil {
+				return nil, err
+			}
+			commandNode.Properties = properties.Bytes()
+
+			// Custom suggestions can only be computed by us, so the client has to ask for them
+			if node.HasSuggestions() {
+				commandNode.Flags |= packets.SuggestionsTypeCommandNodeFlag
+				commandNode.SuggestionsType = "minecraft:ask_server"
+			}
+		}
+
+		packet.Nodes = append(packet.Nodes, commandNode)
+	}
+	return packet, nil
+}
+
+func handleTabComplete(player Player, packet *packets.PacketPlayInTabComplete) error {
+	dispatcher := player.GetServer().GetCommandDispatcher()
+
+	if player.GetProtocol() >= protocol.V1_13 {
+		// Modern clients only ask for command arguments, always prefixed with a slash
+		text := strings.TrimPrefix(packet.Text, "/")
+		offset := len(packet.Text) - len(text)
+
+		start, suggestions := dispatcher.Suggest(player, text)
+		response := &packets.PacketPlayOutTabComplete{
+			TransactionID: packet.TransactionID,
+			Start:         int32(start + offset),
+			Length:        int32(len(text) - start),
+		}
+		for _, suggestion := range suggestions {
+			response.Matches = append(response.Matches, packets.TabCompleteMatch{
+				Match:   suggestion.Text,
+				Tooltip: suggestion.Tooltip,
+			})
+		}
+		return player.SendPacket(response)
+	}
+
+	// Legacy clients replace the last word with the match, so command names keep their slash
+	response := &packets.PacketPlayOutTabComplete{}
+	if strings.HasPrefix(packet.Text, "/") {
+		start, suggestions := dispatcher.Suggest(player, packet.Text[1:])
+		for _, suggestion := range suggestions {
+			match := suggestion.Text
+			if start == 0 {
+				match = "/" + match
+			}
+			response.Matches = append(response.Matches, packets.TabCompleteMatch{Match: match})
+		}
+	} else {
+		word := strings.ToLower(packet.Text[strings.LastIndex(packet.Text, " ")+1:])
+		for _, online := range player.GetServer().GetPlayers() {
+			if strings.HasPrefix(strings.ToLower(online.GetUsername()), word) {
+				response.Matches = append(response.Matches, packets.TabCompleteMatch{Match: online.GetUsername()})
+			}
+		}
+	}
+	return player.SendPacket(response)
+}
+
+// dispatchPlayerCommand runs a command a player typed in the chat, without its slash
+func dispatchPlayerCommand(player Player, command string) {
+	log.Log.WithValues(
+		"name", player.GetUsername(),
+		"uuid", player.GetUniqueID(),
+		"command", "/"+command,
+	).Info("player issued server command")
+	player.GetServer().DispatchCommand(player, command)
+}
@@ -1,20 +1,74 @@
 package server
 
+import "time"
+
 type (
 	Config struct {
 		Host        string
 		Port        int
+		Whitelist   bool
+		Limits      LimitsConf
+		Timeouts    TimeoutsConf
 		World       WorldConf
 		Compression CompressionConf
+		RCon        RConConf
+		Query       QueryConf
+		Metrics     MetricsConf
+		API         APIConf
 	}
 
 	WorldConf struct {
 		Schematic      string
 		RenderDistance int
+		GameMode       string
+		Difficulty     string
+	}
+
+	LimitsConf struct {
+		ConnectionThrottle time.Duration
+		MaxHandshaking     int
+		MaxLogin           int
+		LoginRate          float64
+		LoginBurst         int
+		PreLoginTimeout    time.Duration
+		WriteQueue         int
+	}
+
+	TimeoutsConf struct {
+		Read       time.Duration
+		Write      time.Duration
+		LoginQuery time.Duration
+		PreLogin   time.Duration
 	}
 
 	CompressionConf struct {
 		Threshold int
 		Level     int
 	}
+
+	RConConf struct {
+		Enabled  bool
+		Host     string
+		Port     int
+		Password string
+	}
+
+	QueryConf struct {
+		Enabled bool
+		Host    string
+		Port    int
+	}
+
+	MetricsConf struct {
+		Enabled bool
+		Host    string
+		Port    int
+	}
+
+	APIConf struct {
+		Enabled bool
+		Host    string
+		Port    int
+		Token   string
+	}
 )
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
@@ -8,19 +10,56 @@ import (
 	"github.com/r4g3baby/mcserver/pkg/log"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
-	"github.com/r4g3baby/mcserver/pkg/util"
 	"github.com/r4g3baby/mcserver/pkg/util/chat"
 	"github.com/r4g3baby/mcserver/pkg/util/pools"
 	"io"
 	"net"
+	"os"
 	"reflect"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
+const (
+	// DisconnectDelay gives clients time to read the disconnect packet before the socket is closed
+	DisconnectDelay = 250 * time.Millisecond
+
+	// DefaultWriteQueue is the number of packets that can wait to be written when no limit is configured
+	DefaultWriteQueue = 1024
+	writeBufferSize   = 32 * 1024
+)
+
+var (
+	ErrSlowConnection = errors.New("connection write queue is full")
+
+	disconnectedReason = coloredText("Disconnected", &chat.Red)
+	timedOutReason     = coloredText("Timed out", &chat.Red)
+
+	statusVersion     = chat.ColorChar + "cHello World!"
+	supportedVersions = "1.8 to 1.20.2"
+	statusDescription = []chat.Component{
+		&chat.TextComponent{
+			Text: "Hello World!\n",
+			BaseComponent: chat.BaseComponent{
+				Color: &chat.Blue,
+			},
+		},
+		&chat.TextComponent{
+			Text: "Hello World!",
+			BaseComponent: chat.BaseComponent{
+				Color: &chat.Color{Hex: "c33131"},
+			},
+		},
+	}
+)
+
 type (
 	Connection interface {
 		RemoteAddr() net.Addr
+		// Context is cancelled once the connection closes or the server stops
+		Context() context.Context
 
 		GetServer() Server
 		SetUniqueID(uniqueID uuid.UUID)
@@ -31,17 +70,33 @@ type (
 		GetProtocol() protocol.Protocol
 		SetState(state protocol.State)
 		GetState() protocol.State
+		switchState(state protocol.State)
+		acknowledgeState()
+		getInboundState() protocol.State
+		hasJoined() bool
 		UseCompression() bool
 		setCompressionThreshold(threshold int)
 		GetCompressionThreshold() int
 		SetCompressionLevel(level int)
 		GetCompressionLevel() int
 
+		setDisconnectReason(reason []chat.Component)
+		GetDisconnectReason() []chat.Component
+
+		// SendLoginQuery returns the data the client answered with and whether it understood the channel
+		SendLoginQuery(channel string, data []byte) ([]byte, bool, error)
+
 		Close() error
-		DelayedClose(delay time.Duration) error
+		DelayedClose(delay time.Duration)
+		// Flush blocks until every queued packet was written to the socket
+		Flush() error
+		requestFlush()
 
+		updateReadDeadline() error
+		handleReadError(err error)
 		ReadPacket() error
 		WritePacket(packet protocol.Packet) error
+		writePacket(packet protocol.Packet, cache frameCache) error
 	}
 
 	connection struct {
@@ -49,12 +104,48 @@ type (
 
 		server Server
 
-		mutex       sync.RWMutex
-		uniqueID    uuid.UUID
-		username    string
-		protocol    protocol.Protocol
-		state       protocol.State
-		compression compression
+		reader        *bufio.Reader
+		ctx           context.Context
+		cancel        context.CancelFunc
+		closeOnce     sync.Once
+		writeMutex    sync.Mutex
+		queue         chan []byte
+		flush         chan chan error
+		timeouts      TimeoutsConf
+		loginDeadline time.Time
+
+		mutex            sync.RWMutex
+		uniqueID         uuid.UUID
+		username         string
+		protocol         protocol.Protocol
+		state            protocol.State
+		inboundState     protocol.State
+		joined           bool
+		inbound          *packets.Table
+		outbound         *packets.Table
+		closed           bool
+		disconnectReason []chat.Component
+		compression      compression
+		nextQueryID      int32
+		loginQueries     map[int32]chan *packets.PacketLoginInPluginResponse
+	}
+
+	// frameKey holds everything that changes how a packet is encoded
+	frameKey struct {
+		protocol  protocol.Protocol
+		state     protocol.State
+		threshold int
+		level     int
+		// table is the clientbound packets of the protocol and state above
+		table *packets.Table
+	}
+
+	frameCache map[frameKey]*encodedPacket
+
+	encodedPacket struct {
+		frame      []byte
+		packetID   int32
+		dataLength int
 	}
 
 	compression struct {
@@ -65,6 +156,10 @@ type (
 	}
 )
 
+func (conn *connection) Context() context.Context {
+	return conn.ctx
+}
+
 func (conn *connection) GetServer() Server {
 	return conn.server
 }
@@ -97,6 +192,7 @@ func (conn *connection) SetProtocol(protocol protocol.Protocol) {
 	conn.mutex.Lock()
 	defer conn.mutex.Unlock()
 	conn.protocol = protocol
+	conn.updateTables()
 }
 
 func (conn *connection) GetProtocol() protocol.Protocol {
@@ -108,15 +204,71 @@ func (conn *connection) GetProtocol() protocol.Protocol {
 func (conn *connection) SetState(state protocol.State) {
 	conn.mutex.Lock()
 	defer conn.mutex.Unlock()
+	conn.setState(state)
+	conn.inboundState = state
+	conn.updateTables()
+}
+
+// switchState only moves the packets we send to the given state, the ones we read
+// keep their state until the client acknowledges the switch with acknowledgeState
+func (conn *connection) switchState(state protocol.State) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.setState(state)
+	conn.updateTables()
+}
+
+func (conn *connection) acknowledgeState() {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.inboundState = conn.state
+	conn.updateTables()
+}
+
+// setState must be called with the mutex held
+func (conn *connection) setState(state protocol.State) {
+	if !conn.closed {
+		conn.leaveState(conn.state)
+		conn.enterState(state)
+	}
 	conn.state = state
+	if state == protocol.Play {
+		conn.joined = true
+	}
+}
+
+// updateTables resolves the packets of the current protocol and states, must be called with the mutex held
+func (conn *connection) updateTables() {
+	conn.inbound = packets.Lookup(conn.protocol, conn.inboundState, protocol.ServerBound)
+	conn.outbound = packets.Lookup(conn.protocol, conn.state, protocol.ClientBound)
 }
 
+func (conn *connection) getInbound() *packets.Table {
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+	return conn.inbound
+}
+
+// GetState returns the state of the packets we send, which the client can still be switching to
 func (conn *connection) GetState() protocol.State {
 	conn.mutex.RLock()
 	defer conn.mutex.RUnlock()
 	return conn.state
 }
 
+func (conn *connection) getInboundState() protocol.State {
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+	return conn.inboundState
+}
+
+// hasJoined reports whether the connection ever reached the play state, it stays true while reconfiguring
+func (conn *connection) hasJoined() bool {
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+	return conn.joined
+}
+
 func (conn *connection) UseCompression() bool {
 	conn.compression.mutex.RLock()
 	defer conn.compression.mutex.RUnlock()
@@ -148,73 +300,280 @@ func (conn *connection) GetCompressionLevel() int {
 	return conn.compression.level
 }
 
+// setDisconnectReason keeps the first reason, later ones are usually a consequence of it
+func (conn *connection) setDisconnectReason(reason []chat.Component) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if conn.disconnectReason == nil {
+		conn.disconnectReason = reason
+	}
+}
+
+func (conn *connection) GetDisconnectReason() []chat.Component {
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+	return conn.disconnectReason
+}
+
+// Close can be called more than once, every call waits for the first one to
+// cancel the context and remove the player
 func (conn *connection) Close() error {
-	conn.server.removePlayer(conn.GetUniqueID())
-	return conn.Conn.Close()
+	err := conn.Conn.Close()
+	conn.closeOnce.Do(func() {
+		conn.mutex.Lock()
+		conn.closed = true
+		conn.leaveState(conn.state)
+		if conn.disconnectReason == nil {
+			conn.disconnectReason = disconnectedReason
+		}
+		conn.mutex.Unlock()
+
+		conn.cancel()
+		conn.server.removePlayer(conn)
+	})
+	return err
 }
 
-func (conn *connection) DelayedClose(delay time.Duration) error {
-	time.Sleep(delay)
-	return conn.Close()
+func (conn *connection) enterState(state protocol.State) {
+	connectionsGauge.WithLabelValues(state.String()).Inc()
+	conn.server.getConnectionLimiter().enter(state)
 }
 
-func (conn *connection) ReadPacket() error {
-	length, err := conn.readLength()
-	if err != nil {
+func (conn *connection) leaveState(state protocol.State) {
+	connectionsGauge.WithLabelValues(state.String()).Dec()
+	conn.server.getConnectionLimiter().leave(state)
+}
+
+// DelayedClose returns right away, queued packets are flushed in the background
+// and the connection is closed once the delay passes
+func (conn *connection) DelayedClose(delay time.Duration) {
+	go func() {
+		if err := conn.Flush(); err == nil {
+			time.Sleep(delay)
+		}
+
+		if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			log.Log.WithValues(
+				"connection", conn.RemoteAddr(),
+			).Error(err, "got error while closing connection")
+		}
+	}()
+}
+
+func (conn *connection) Flush() error {
+	done := make(chan error, 1)
+	select {
+	case conn.flush <- done:
+	case <-conn.ctx.Done():
+		return net.ErrClosed
+	}
+
+	select {
+	case err := <-done:
 		return err
+	case <-conn.ctx.Done():
+		return net.ErrClosed
 	}
+}
 
-	if length < 0 || length > 2147483647 {
-		return errors.New("received invalid packet length")
+// requestFlush doesn't wait for the flush, it's called for every player once per tick
+func (conn *connection) requestFlush() {
+	select {
+	case conn.flush <- nil:
+	default:
+		// A flush is already pending
 	}
+}
 
-	var payload = make([]byte, length)
-	if _, err := io.ReadFull(conn, payload); err != nil {
-		return err
+// enqueue never blocks, a client that can't keep up is disconnected instead of stalling the server
+func (conn *connection) enqueue(frame []byte) error {
+	select {
+	case <-conn.ctx.Done():
+		return net.ErrClosed
+	default:
 	}
 
-	packetData := pools.Buffer.Get(payload)
-	defer pools.Buffer.Put(packetData)
+	select {
+	case conn.queue <- frame:
+		return nil
+	default:
+		log.Log.WithValues(
+			"connection", conn.RemoteAddr(),
+			"queued", len(conn.queue),
+		).Info("disconnecting slow connection")
+		conn.setDisconnectReason(coloredText("Connection is too slow", &chat.Red))
+
+		// Closed asynchronously as the caller holds the write mutex
+		go func() {
+			_ = conn.Close()
+		}()
+		return ErrSlowConnection
+	}
+}
 
-	if conn.UseCompression() {
-		dataLength, err := packetData.ReadVarInt()
-		if err != nil {
-			return err
-		}
+// writeLoop is the only goroutine writing to the socket, packets sent while playing
+// are buffered until the next tick while everything else is flushed right away
+func (conn *connection) writeLoop() {
+	writer := bufio.NewWriterSize(conn.Conn, writeBufferSize)
+	for {
+		select {
+		case <-conn.ctx.Done():
+			return
+		case frame := <-conn.queue:
+			if err := conn.writeFrame(writer, frame); err != nil {
+				conn.handleWriteError(err)
+				return
+			}
 
-		if dataLength > int32(conn.GetCompressionThreshold()) {
-			return errors.New("fat")
-		} else if dataLength > 0 {
-			var uncompressedData = make([]byte, length)
-			if err := func(zlibReader io.ReadCloser, err error) error {
-				if err != nil {
-					return err
-				}
-				defer pools.Zlib.PutReader(zlibReader)
-				if _, err := io.ReadFull(zlibReader, uncompressedData); err != nil {
-					return err
+			if len(conn.queue) == 0 && conn.GetState() != protocol.Play {
+				if err := conn.flushWriter(writer); err != nil {
+					conn.handleWriteError(err)
+					return
 				}
-				return nil
-			}(pools.Zlib.GetReader(packetData)); err != nil {
+			}
+		case done := <-conn.flush:
+			err := conn.drainQueue(writer)
+			if err == nil {
+				err = conn.flushWriter(writer)
+			}
+
+			if done != nil {
+				done <- err
+			}
+			if err != nil {
+				conn.handleWriteError(err)
+				return
+			}
+		}
+	}
+}
+
+func (conn *connection) drainQueue(writer *bufio.Writer) error {
+	for {
+		select {
+		case frame := <-conn.queue:
+			if err := conn.writeFrame(writer, frame); err != nil {
 				return err
 			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (conn *connection) writeFrame(writer *bufio.Writer, frame []byte) error {
+	// The buffer writes to the socket by itself once it's full
+	if len(frame) > writer.Available() {
+		if err := conn.updateWriteDeadline(); err != nil {
+			return err
+		}
+	}
+	_, err := writer.Write(frame)
+	return err
+}
+
+func (conn *connection) flushWriter(writer *bufio.Writer) error {
+	if writer.Buffered() == 0 {
+		return nil
+	}
+
+	if err := conn.updateWriteDeadline(); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
 
-			packetData.Reset()
-			_, _ = packetData.Write(uncompressedData)
+func (conn *connection) updateWriteDeadline() error {
+	if timeout := conn.timeouts.Write; timeout > 0 {
+		return conn.SetWriteDeadline(time.Now().Add(timeout))
+	}
+	return nil
+}
+
+func (conn *connection) handleWriteError(err error) {
+	switch {
+	case errors.Is(err, net.ErrClosed):
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		conn.setDisconnectReason(timedOutReason)
+	case errors.Is(err, syscall.EPIPE), errors.Is(err, syscall.ECONNRESET):
+		conn.setDisconnectReason(disconnectedReason)
+	default:
+		log.Log.WithValues(
+			"connection", conn.RemoteAddr(),
+		).Error(err, "got error during packet write")
+		conn.setDisconnectReason(coloredText("Internal Exception: "+err.Error(), &chat.Red))
+	}
+	_ = conn.Close()
+}
+
+// updateReadDeadline is called before every read, until the player logs in
+// the deadline set when the client connected also applies
+func (conn *connection) updateReadDeadline() error {
+	var deadline time.Time
+	if timeout := conn.timeouts.Read; timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	if !conn.loginDeadline.IsZero() && !conn.hasJoined() {
+		if deadline.IsZero() || conn.loginDeadline.Before(deadline) {
+			deadline = conn.loginDeadline
 		}
 	}
+	return conn.SetReadDeadline(deadline)
+}
+
+// handleReadError records why the connection is going away, logging anything unexpected
+func (conn *connection) handleReadError(err error) {
+	switch {
+	case errors.Is(err, net.ErrClosed):
+		// Closed on our side, whoever closed it already set the reason
+	case errors.Is(err, io.EOF), errors.Is(err, syscall.ECONNRESET):
+		conn.setDisconnectReason(disconnectedReason)
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		if !conn.hasJoined() && !conn.loginDeadline.IsZero() && !time.Now().Before(conn.loginDeadline) {
+			conn.server.getConnectionLimiter().reject(conn.RemoteAddr(), PreLoginTimeoutRejection)
+		}
+		conn.setDisconnectReason(timedOutReason)
+	default:
+		log.Log.WithValues(
+			"connection", conn.RemoteAddr(),
+		).Error(err, "got error during packet read")
+		conn.setDisconnectReason(coloredText("Internal Exception: "+err.Error(), &chat.Red))
+	}
+}
+
+func (conn *connection) ReadPacket() error {
+	// Compression can be enabled by the login while we wait, so only check it once the client sent something
+	if _, err := conn.reader.Peek(1); err != nil {
+		return err
+	}
+
+	threshold := -1
+	if conn.UseCompression() {
+		threshold = conn.GetCompressionThreshold()
+	}
+
+	data, wireLength, err := readFrame(conn.reader, threshold)
+	if err != nil {
+		return err
+	}
+	observeBytes(protocol.ServerBound, wireLength, len(data))
+
+	packetData := pools.Buffer.Get(data)
+	defer pools.Buffer.Put(packetData)
 
 	packetID, err := packetData.ReadVarInt()
 	if err != nil {
 		return err
 	}
 
-	packet, err := packets.Get(conn.GetProtocol(), conn.GetState(), protocol.ServerBound, packetID)
+	packet, err := conn.getInbound().New(packetID)
 	if err != nil {
+		observePacket(nil, protocol.ServerBound)
 		if debugLog := log.Log.V(1); debugLog.Enabled() {
 			debugLog.WithValues(
 				"id", fmt.Sprintf("%#0X", packetID),
-				"state", conn.GetState(),
+				"state", conn.getInboundState(),
 				"protocol", int32(conn.GetProtocol()),
 				"compression", conn.UseCompression(),
 			).V(1).Info("received unknown packet")
@@ -226,7 +585,7 @@ func (conn *connection) ReadPacket() error {
 		debugLog.WithValues(
 			"id", fmt.Sprintf("%#0X", packetID),
 			"type", reflect.TypeOf(packet),
-			"state", conn.GetState(),
+			"state", conn.getInboundState(),
 			"protocol", int32(conn.GetProtocol()),
 			"compression", conn.UseCompression(),
 		).V(1).Info("received packet")
@@ -235,6 +594,7 @@ func (conn *connection) ReadPacket() error {
 	if err := packet.Read(conn.GetProtocol(), packetData); err != nil {
 		return err
 	}
+	observePacket(packet, protocol.ServerBound)
 
 	player := conn.server.GetPlayer(conn.GetUniqueID())
 	event := NewPacketEvent(conn, player, packet)
@@ -247,7 +607,12 @@ func (conn *connection) ReadPacket() error {
 }
 
 func (conn *connection) handlePacketRead(packet protocol.Packet) error {
-	switch conn.GetState() {
+	// Clients that were told to disconnect are only waiting to be closed
+	if conn.GetDisconnectReason() != nil {
+		return nil
+	}
+
+	switch conn.getInboundState() {
 	case protocol.Handshaking:
 		switch p := packet.(type) {
 		case *packets.PacketHandshakingStart:
@@ -257,7 +622,18 @@ func (conn *connection) handlePacketRead(packet protocol.Packet) error {
 			case 1:
 				conn.SetState(protocol.Status)
 			case 2:
+				// Checked before switching so the connection doesn't count towards its own limit
+				reason := conn.server.getConnectionLimiter().allowLogin(conn.RemoteAddr())
 				conn.SetState(protocol.Login)
+				if reason == nil {
+					reason = checkProtocol(conn.GetProtocol())
+				}
+
+				if reason != nil {
+					// Set right away so the login start clients send along with the handshake is ignored
+					conn.setDisconnectReason(reason)
+					return conn.WritePacket(&packets.PacketLoginOutDisconnect{Reason: reason})
+				}
 			default:
 				return errors.New("received invalid nextState")
 			}
@@ -268,30 +644,17 @@ func (conn *connection) handlePacketRead(packet protocol.Packet) error {
 			return conn.WritePacket(&packets.PacketStatusOutResponse{
 				Response: packets.Response{
 					Version: packets.Version{
-						Name:     chat.ColorChar + "cHello World!",
-						Protocol: int(conn.GetProtocol()),
+						Name:     statusVersion,
+						Protocol: int(statusProtocol(conn.GetProtocol())),
 					},
 					Players: packets.Players{
 						Max:    conn.server.GetPlayerCount(),
 						Online: conn.server.GetPlayerCount(),
 						Sample: []packets.Sample{
-							{chat.ColorChar + "bHello World!", uuid.Nil},
-						},
-					},
-					Description: []chat.Component{
-						&chat.TextComponent{
-							Text: "Hello World!\n",
-							BaseComponent: chat.BaseComponent{
-								Color: &chat.Blue,
-							},
-						},
-						&chat.TextComponent{
-							Text: "Hello World!",
-							BaseComponent: chat.BaseComponent{
-								Color: &chat.Color{Hex: "c33131"},
-							},
+							{Name: chat.ColorChar + "bHello World!", Id: uuid.Nil},
 						},
 					},
+					Description: statusDescription,
 				},
 			})
 		case *packets.PacketStatusInPing:
@@ -302,90 +665,176 @@ func (conn *connection) handlePacketRead(packet protocol.Packet) error {
 	case protocol.Login:
 		switch p := packet.(type) {
 		case *packets.PacketLoginInStart:
-			conn.SetUsername(p.Username)
-			conn.SetUniqueID(util.NameUUIDFromBytes([]byte("OfflinePlayer:" + conn.GetUsername())))
-
-			player, online := conn.server.createPlayer(conn)
-			if online {
-				return conn.WritePacket(&packets.PacketLoginOutDisconnect{
-					Reason: []chat.Component{
-						&chat.TextComponent{
-							Text: "You are already connected to this server!",
-							BaseComponent: chat.BaseComponent{
-								Color: &chat.Red,
-							},
-						},
-					},
-				})
+			if conn.GetUsername() != "" {
+				return errors.New("received duplicate login start")
 			}
 
-			if err := conn.WritePacket(&packets.PacketLoginOutCompression{
-				Threshold: int32(conn.server.GetConfig().Compression.Threshold),
-			}); err != nil {
-				return err
-			}
+			conn.SetUsername(p.Username)
+			conn.SetUniqueID(offlineUniqueID(conn.GetUsername()))
 
-			if err := conn.WritePacket(&packets.PacketLoginOutSuccess{
-				UniqueID: player.GetUniqueID(),
-				Username: player.GetUsername(),
-			}); err != nil {
-				return err
+			go func() {
+				if err := conn.login(); err != nil {
+					conn.handleReadError(err)
+					_ = conn.Close()
+				}
+			}()
+		case *packets.PacketLoginInPluginResponse:
+			conn.answerLoginQuery(p)
+		case *packets.PacketLoginInAcknowledged:
+			conn.acknowledgeState()
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				return conn.configure(player)
 			}
-
-			if err := conn.WritePacket(&packets.PacketPlayOutJoinGame{
-				EntityID:         1,
-				Hardcore:         false,
-				Gamemode:         1,
-				PreviousGamemode: -1,
-				WorldNames:       []string{"minecraft:overworld"},
-				DimensionCodec:   protocol.DefaultDimensionCodec,
-				Dimension:        protocol.Overworld,
-				WorldName:        "minecraft:overworld",
-				DimensionID:      0,
-				HashedSeed:       0,
-				MaxPlayers:       20,
-				LevelType:        "default",
-				ViewDistance:     10,
-				ReducedDebug:     false,
-				RespawnScreen:    true,
-				IsDebug:          false,
-				IsFlat:           false,
-			}); err != nil {
-				return err
+		}
+	case protocol.Configuration:
+		switch p := packet.(type) {
+		case *packets.PacketConfigurationInKeepAlive:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				handleKeepAlive(player, p.KeepAliveID)
 			}
-
-			if err := conn.WritePacket(&packets.PacketPlayOutServerDifficulty{
-				Difficulty: 1,
-				Locked:     true,
-			}); err != nil {
-				return err
+		case *packets.PacketConfigurationInPluginMessage:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				handlePluginMessage(player, p.Channel, p.Data)
 			}
-
-			if err := conn.WritePacket(&packets.PacketPlayOutPositionAndLook{
-				X: 0.5,
-				Y: 65,
-				Z: 0.5,
-			}); err != nil {
-				return err
+		case *packets.PacketConfigurationInFinish:
+			conn.acknowledgeState()
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				return conn.joinGame(player)
 			}
-
-			return conn.server.GetWorld().SendChunks(player)
 		}
 	case protocol.Play:
 		switch p := packet.(type) {
 		case *packets.PacketPlayInKeepAlive:
 			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
-				if player.IsKeepAlivePending() && p.KeepAliveID == player.GetLastKeepAliveID() {
-					player.setLatency(time.Since(player.GetLastKeepAliveTime()))
-					player.setKeepAlivePending(false)
-				}
+				handleKeepAlive(player, p.KeepAliveID)
+			}
+		case *packets.PacketPlayInConfigurationAcknowledged:
+			conn.acknowledgeState()
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				return conn.configure(player)
+			}
+		case *packets.PacketPlayInPluginMessage:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				handlePluginMessage(player, p.Channel, p.Data)
+			}
+		case *packets.PacketPlayInChatMessage:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil && strings.HasPrefix(p.Message, "/") {
+				dispatchPlayerCommand(player, p.Message[1:])
+			}
+		case *packets.PacketPlayInChatCommand:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				dispatchPlayerCommand(player, p.Command)
+			}
+		case *packets.PacketPlayInTabComplete:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				return handleTabComplete(player, p)
+			}
+		case *packets.PacketPlayInAbilities:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				player.setFlying(p.Flags&packets.FlyingAbilityFlag != 0)
+			}
+		case *packets.PacketPlayInPosition:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				player.setPosition(Position{X: p.X, Y: p.Y, Z: p.Z})
+			}
+		case *packets.PacketPlayInPositionAndLook:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				player.setPosition(Position{X: p.X, Y: p.Y, Z: p.Z})
 			}
 		}
 	}
 	return nil
 }
 
+// configure sends what 1.20.2+ clients need before they can join, the client answers the finish once it's done
+func (conn *connection) configure(player Player) error {
+	if err := sendServerChannels(player); err != nil {
+		return err
+	}
+
+	if err := conn.WritePacket(&packets.PacketConfigurationOutFeatureFlags{
+		Flags: []string{"minecraft:vanilla"},
+	}); err != nil {
+		return err
+	}
+
+	if err := conn.WritePacket(&packets.PacketConfigurationOutRegistryData{
+		DimensionCodec: protocol.DefaultDimensionCodec,
+	}); err != nil {
+		return err
+	}
+
+	return conn.WritePacket(&packets.PacketConfigurationOutFinish{})
+}
+
+// joinGame sends everything the client needs to start playing, players that
+// were sent back into configuration join again where they were
+func (conn *connection) joinGame(player Player) error {
+	if err := conn.WritePacket(&packets.PacketPlayOutJoinGame{
+		EntityID:           1,
+		Hardcore:           false,
+		Gamemode:           uint8(player.GetGameMode()),
+		PreviousGamemode:   -1,
+		WorldNames:         []string{"minecraft:overworld"},
+		DimensionCodec:     protocol.DefaultDimensionCodec,
+		Dimension:          conn.server.GetWorld().GetDimension(),
+		WorldName:          "minecraft:overworld",
+		DimensionID:        0,
+		HashedSeed:         0,
+		Difficulty:         uint8(conn.server.GetWorld().GetDifficulty()),
+		MaxPlayers:         20,
+		LevelType:          "default",
+		ViewDistance:       10,
+		SimulationDistance: 10,
+		ReducedDebug:       false,
+		RespawnScreen:      true,
+		IsDebug:            false,
+		IsFlat:             false,
+	}); err != nil {
+		return err
+	}
+
+	// Newer clients got these during configuration
+	if conn.GetProtocol() < protocol.V1_20_2 {
+		if err := sendServerChannels(player); err != nil {
+			return err
+		}
+	}
+
+	if err := conn.WritePacket(&packets.PacketPlayOutServerDifficulty{
+		Difficulty: uint8(conn.server.GetWorld().GetDifficulty()),
+		Locked:     true,
+	}); err != nil {
+		return err
+	}
+
+	if err := player.sendAbilities(); err != nil {
+		return err
+	}
+
+	if err := player.UpdateCommands(); err != nil {
+		return err
+	}
+
+	position := player.GetPosition()
+	if err := conn.WritePacket(&packets.PacketPlayOutPositionAndLook{
+		X: position.X,
+		Y: position.Y,
+		Z: position.Z,
+	}); err != nil {
+		return err
+	}
+
+	return conn.server.GetWorld().SendChunks(player)
+}
+
 func (conn *connection) WritePacket(packet protocol.Packet) error {
+	return conn.writePacket(packet, nil)
+}
+
+// writePacket reuses the frames other connections with the same settings
+// already encoded when given a cache, it's how broadcasts encode packets once
+func (conn *connection) writePacket(packet protocol.Packet, cache frameCache) error {
 	player := conn.server.GetPlayer(conn.GetUniqueID())
 	event := NewPacketEvent(conn, player, packet)
 	conn.server.FireEvent(OnPacketWriteEvent, event)
@@ -393,20 +842,80 @@ func (conn *connection) WritePacket(packet protocol.Packet) error {
 		return nil
 	}
 
+	// Held until the packet is queued so frames keep their order and are
+	// encoded with the state and compression set by the packets before them
+	conn.writeMutex.Lock()
+	defer conn.writeMutex.Unlock()
+
+	key := conn.frameKey()
+	encoded, ok := cache[key]
+	if !ok {
+		var err error
+		if encoded, err = encodePacket(key, packet); err != nil {
+			return err
+		}
+		if cache != nil {
+			cache[key] = encoded
+		}
+	}
+
+	if err := conn.handlePrePacketWrite(packet); err != nil {
+		return err
+	}
+
+	if err := conn.enqueue(encoded.frame); err != nil {
+		return err
+	}
+	observePacket(packet, protocol.ClientBound)
+	observeBytes(protocol.ClientBound, len(encoded.frame), encoded.dataLength)
+
+	if debugLog := log.Log.V(1); debugLog.Enabled() {
+		debugLog.WithValues(
+			"id", fmt.Sprintf("%#0X", encoded.packetID),
+			"type", reflect.TypeOf(packet),
+			"state", key.state,
+			"protocol", int32(key.protocol),
+			"compression", key.threshold >= 0,
+		).V(1).Info("queued packet")
+	}
+
+	return conn.handlePostPacketWrite(packet)
+}
+
+func (conn *connection) frameKey() frameKey {
+	conn.mutex.RLock()
+	key := frameKey{
+		protocol:  conn.protocol,
+		state:     conn.state,
+		threshold: -1,
+		table:     conn.outbound,
+	}
+	conn.mutex.RUnlock()
+
+	if conn.UseCompression() {
+		key.threshold = conn.GetCompressionThreshold()
+		key.level = conn.GetCompressionLevel()
+	}
+	return key
+}
+
+// encodePacket returns the packet framed as it's sent on the wire, the frame is
+// never modified afterwards so it can be queued on more than one connection
+func encodePacket(key frameKey, packet protocol.Packet) (*encodedPacket, error) {
 	packetData := pools.Buffer.Get(nil)
 	defer pools.Buffer.Put(packetData)
 
-	packetID, err := packets.GetID(conn.GetProtocol(), conn.GetState(), protocol.ClientBound, packet)
+	packetID, err := key.table.ID(packet)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := packetData.WriteVarInt(packetID); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := packet.Write(conn.GetProtocol(), packetData); err != nil {
-		return err
+	if err := packet.Write(key.protocol, packetData); err != nil {
+		return nil, err
 	}
 
 	dataLength := packetData.Len()
@@ -414,78 +923,76 @@ func (conn *connection) WritePacket(packet protocol.Packet) error {
 	buffer := pools.Buffer.Get(nil)
 	defer pools.Buffer.Put(buffer)
 
-	if conn.UseCompression() {
+	if key.threshold >= 0 {
 		data := pools.Buffer.Get(nil)
 		defer pools.Buffer.Put(data)
 
-		if dataLength >= conn.GetCompressionThreshold() {
+		if dataLength >= key.threshold {
 			if err := data.WriteVarInt(int32(dataLength)); err != nil {
-				return err
+				return nil, err
 			}
 
-			level := conn.GetCompressionLevel()
 			if err := func(zlibWriter *zlib.Writer, err error) error {
 				if err != nil {
 					return err
 				}
-				defer pools.Zlib.PutWriter(zlibWriter, level)
+				defer pools.Zlib.PutWriter(zlibWriter, key.level)
 				if _, err := packetData.WriteTo(zlibWriter); err != nil {
 					return err
 				}
 				return nil
-			}(pools.Zlib.GetWriter(data, level)); err != nil {
-				return err
+			}(pools.Zlib.GetWriter(data, key.level)); err != nil {
+				return nil, err
 			}
 		} else {
 			if err := data.WriteVarInt(0); err != nil {
-				return err
+				return nil, err
 			}
 
 			if _, err := packetData.WriteTo(data); err != nil {
-				return err
+				return nil, err
 			}
 		}
 
 		if err := buffer.WriteVarInt(int32(data.Len())); err != nil {
-			return err
+			return nil, err
 		}
 
 		if _, err := data.WriteTo(buffer); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		if err := buffer.WriteVarInt(int32(dataLength)); err != nil {
-			return err
+			return nil, err
 		}
 
 		if _, err := packetData.WriteTo(buffer); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	if err := conn.handlePrePacketWrite(packet); err != nil {
-		return err
-	}
-
-	if _, err := buffer.WriteTo(conn); err != nil {
-		return err
-	}
+	// The buffer goes back to the pool, the frame needs its own copy
+	frame := make([]byte, buffer.Len())
+	copy(frame, buffer.Bytes())
 
-	if debugLog := log.Log.V(1); debugLog.Enabled() {
-		debugLog.WithValues(
-			"id", fmt.Sprintf("%#0X", packetID),
-			"type", reflect.TypeOf(packet),
-			"state", conn.GetState(),
-			"protocol", int32(conn.GetProtocol()),
-			"compression", conn.UseCompression(),
-		).V(1).Info("sent packet")
-	}
-
-	return conn.handlePostPacketWrite(packet)
+	return &encodedPacket{
+		frame:      frame,
+		packetID:   packetID,
+		dataLength: dataLength,
+	}, nil
 }
 
 func (conn *connection) handlePrePacketWrite(packet protocol.Packet) error {
 	switch conn.GetState() {
+	case protocol.Configuration:
+		switch p := packet.(type) {
+		case *packets.PacketConfigurationOutKeepAlive:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				player.setLastKeepAliveTime(time.Now())
+				player.setLastKeepAliveID(p.KeepAliveID)
+				player.setKeepAlivePending(true)
+			}
+		}
 	case protocol.Play:
 		switch p := packet.(type) {
 		case *packets.PacketPlayOutKeepAlive:
@@ -494,6 +1001,27 @@ func (conn *connection) handlePrePacketWrite(packet protocol.Packet) error {
 				player.setLastKeepAliveID(p.KeepAliveID)
 				player.setKeepAlivePending(true)
 			}
+		case *packets.PacketPlayOutPositionAndLook:
+			if player := conn.server.GetPlayer(conn.GetUniqueID()); player != nil {
+				// The lowest flag bits mark each coordinate as relative to the current position
+				position := player.GetPosition()
+				if p.Flags&0x01 != 0 {
+					position.X += p.X
+				} else {
+					position.X = p.X
+				}
+				if p.Flags&0x02 != 0 {
+					position.Y += p.Y
+				} else {
+					position.Y = p.Y
+				}
+				if p.Flags&0x04 != 0 {
+					position.Z += p.Z
+				} else {
+					position.Z = p.Z
+				}
+				player.setPosition(position)
+			}
 		}
 	}
 	return nil
@@ -504,68 +1032,123 @@ func (conn *connection) handlePostPacketWrite(packet protocol.Packet) error {
 	case protocol.Status:
 		switch packet.(type) {
 		case *packets.PacketStatusOutPong:
-			if err := conn.Close(); err != nil {
-				if !errors.Is(err, net.ErrClosed) {
-					return err
-				}
-			}
+			conn.DelayedClose(0)
 		}
 	case protocol.Login:
 		switch p := packet.(type) {
 		case *packets.PacketLoginOutDisconnect:
-			if err := conn.DelayedClose(250 * time.Millisecond); err != nil {
-				if !errors.Is(err, net.ErrClosed) {
-					return err
-				}
-			}
+			conn.setDisconnectReason(p.Reason)
+			conn.DelayedClose(DisconnectDelay)
 		case *packets.PacketLoginOutSuccess:
-			conn.SetState(protocol.Play)
+			if conn.GetProtocol() >= protocol.V1_20_2 {
+				conn.switchState(protocol.Configuration)
+			} else {
+				conn.SetState(protocol.Play)
+			}
 		case *packets.PacketLoginOutCompression:
 			conn.setCompressionThreshold(int(p.Threshold))
 		}
+	case protocol.Configuration:
+		switch p := packet.(type) {
+		case *packets.PacketConfigurationOutDisconnect:
+			conn.setDisconnectReason(p.Reason)
+			conn.DelayedClose(DisconnectDelay)
+		case *packets.PacketConfigurationOutFinish:
+			conn.switchState(protocol.Play)
+		}
 	case protocol.Play:
-		switch packet.(type) {
+		switch p := packet.(type) {
 		case *packets.PacketPlayOutDisconnect:
-			if err := conn.DelayedClose(250 * time.Millisecond); err != nil {
-				if !errors.Is(err, net.ErrClosed) {
-					return err
-				}
-			}
+			conn.setDisconnectReason(p.Reason)
+			conn.DelayedClose(DisconnectDelay)
+		case *packets.PacketPlayOutStartConfiguration:
+			conn.switchState(protocol.Configuration)
 		}
 	}
 	return nil
 }
 
-func (conn *connection) readLength() (int32, error) {
-	var result int32 = 0
-	for numRead := 0; ; numRead++ {
-		var read = make([]byte, 1)
-		if _, err := conn.Read(read); err != nil {
-			return result, err
-		}
+// handleKeepAlive records the latency of the player once they answer the pending keep alive
+func handleKeepAlive(player Player, keepAliveID int32) {
+	if player.IsKeepAlivePending() && keepAliveID == player.GetLastKeepAliveID() {
+		latency := time.Since(player.GetLastKeepAliveTime())
+		keepAliveHistogram.Observe(latency.Seconds())
+		player.setLatency(latency)
+		player.setKeepAlivePending(false)
+	}
+}
 
-		result |= int32(read[0]&0x7F) << (7 * numRead)
+// checkProtocol returns why clients using the protocol can't join, if they can't
+func checkProtocol(proto protocol.Protocol) []chat.Component {
+	switch {
+	case protocol.IsSupported(proto):
+		return nil
+	case proto > protocol.HighestProtocol:
+		return coloredText("Outdated server! Only "+supportedVersions+" clients can join", &chat.Red)
+	default:
+		return coloredText("Outdated client! Only "+supportedVersions+" clients can join", &chat.Red)
+	}
+}
 
-		if numRead >= 5 {
-			return result, errors.New("VarInt too big")
-		}
+// statusProtocol echoes the protocol of clients that can join, others are
+// told the newest one we support so they show the server as incompatible
+func statusProtocol(proto protocol.Protocol) protocol.Protocol {
+	if protocol.IsSupported(proto) {
+		return proto
+	}
+	return protocol.HighestProtocol
+}
+
+// checkAccess returns why the connecting player isn't allowed to join, if they aren't
+func (conn *connection) checkAccess() []chat.Component {
+	if ban := conn.server.GetBanList().GetBan(conn.GetUniqueID().String()); ban != nil {
+		return banMessage(ban, false)
+	}
 
-		if (read[0] & 0x80) != 0x80 {
-			break
+	if host, _, err := net.SplitHostPort(conn.RemoteAddr().String()); err == nil {
+		if ban := conn.server.GetIPBanList().GetBan(host); ban != nil {
+			return banMessage(ban, true)
 		}
 	}
-	return result, nil
+
+	if whitelist := conn.server.GetWhitelist(); whitelist.IsEnabled() && !whitelist.Contains(conn.GetUniqueID()) {
+		return coloredText("You are not white-listed on this server!", &chat.Red)
+	}
+	return nil
 }
 
-func newConnection(conn net.Conn, server Server) Connection {
-	return &connection{
-		Conn:     conn,
-		server:   server,
-		uniqueID: uuid.Nil,
-		protocol: protocol.Unknown,
-		state:    protocol.Handshaking,
+func newConnection(ctx context.Context, conn net.Conn, server Server) Connection {
+	ctx, cancel := context.WithCancel(ctx)
+	config := server.GetConfig()
+	connection := &connection{
+		Conn:         conn,
+		reader:       bufio.NewReader(conn),
+		server:       server,
+		ctx:          ctx,
+		cancel:       cancel,
+		timeouts:     config.Timeouts,
+		flush:        make(chan chan error, 1),
+		uniqueID:     uuid.Nil,
+		protocol:     protocol.Unknown,
+		state:        protocol.Handshaking,
+		inboundState: protocol.Handshaking,
 		compression: compression{
-			level: server.GetConfig().Compression.Level,
+			level: config.Compression.Level,
 		},
+		loginQueries: make(map[int32]chan *packets.PacketLoginInPluginResponse),
+	}
+	if timeout := config.Limits.PreLoginTimeout; timeout > 0 {
+		// Idle sockets can't hold on to a handshaking or login slot past this
+		connection.loginDeadline = time.Now().Add(timeout)
 	}
+	queueSize := config.Limits.WriteQueue
+	if queueSize <= 0 {
+		queueSize = DefaultWriteQueue
+	}
+	connection.queue = make(chan []byte, queueSize)
+
+	connection.updateTables()
+	connection.enterState(protocol.Handshaking)
+	go connection.writeLoop()
+	return connection
 }
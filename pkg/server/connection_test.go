@@ -0,0 +1,267 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"net"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClient plays the client side of a connection handled by a test server
+type testClient struct {
+	t         *testing.T
+	conn      net.Conn
+	reader    *bufio.Reader
+	proto     protocol.Protocol
+	state     protocol.State
+	threshold int
+}
+
+// newTestServer creates a server in a temporary directory so the lists it loads and saves start empty
+func newTestServer(t *testing.T, config Config) *server {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	return NewServer(config).(*server)
+}
+
+// connect hands a loopback connection to the server the way Start does,
+// flushing every player like the server tick would
+func connect(t *testing.T, server *server, proto protocol.Protocol) *testClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	accepted, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wait sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		_ = conn.Close()
+		wait.Wait()
+	})
+
+	wait.Add(2)
+	go func() {
+		defer wait.Done()
+		server.handleClient(ctx, accepted)
+	}()
+	go func() {
+		defer wait.Done()
+
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				server.ForEachPlayer(func(player Player) bool {
+					player.getConnection().requestFlush()
+					return true
+				})
+			}
+		}
+	}()
+
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+	return &testClient{
+		t:         t,
+		conn:      conn,
+		reader:    bufio.NewReader(conn),
+		proto:     proto,
+		state:     protocol.Handshaking,
+		threshold: -1,
+	}
+}
+
+func (client *testClient) send(packet protocol.Packet) {
+	client.t.Helper()
+	encoded, err := encodePacket(frameKey{
+		protocol:  client.proto,
+		state:     client.state,
+		threshold: client.threshold,
+		table:     packets.Lookup(client.proto, client.state, protocol.ServerBound),
+	}, packet)
+	if err != nil {
+		client.t.Fatalf("%s: failed to encode %T: %v", client.state, packet, err)
+	}
+
+	if _, err := client.conn.Write(encoded.frame); err != nil {
+		client.t.Fatalf("%s: failed to send %T: %v", client.state, packet, err)
+	}
+}
+
+func (client *testClient) next() protocol.Packet {
+	client.t.Helper()
+	data, _, err := readFrame(client.reader, client.threshold)
+	if err != nil {
+		client.t.Fatalf("%s: failed to read packet: %v", client.state, err)
+	}
+
+	buffer := bytes.NewBuffer(data)
+	packetID, err := buffer.ReadVarInt()
+	if err != nil {
+		client.t.Fatal(err)
+	}
+
+	packet, err := packets.Get(client.proto, client.state, protocol.ClientBound, packetID)
+	if err != nil {
+		client.t.Fatalf("%s: unknown packet 0x%02X: %v", client.state, packetID, err)
+	}
+	if err := packet.Read(client.proto, buffer); err != nil {
+		client.t.Fatalf("%s: failed to read %T: %v", client.state, packet, err)
+	}
+	return packet
+}
+
+// expect skips packets until one of the same type as want arrives
+func (client *testClient) expect(want protocol.Packet) protocol.Packet {
+	client.t.Helper()
+	for {
+		if packet := client.next(); reflect.TypeOf(packet) == reflect.TypeOf(want) {
+			return packet
+		}
+	}
+}
+
+// login logs in and goes through configuration when the protocol has it
+func (client *testClient) login(username string) {
+	client.t.Helper()
+	client.startLogin(username)
+	client.finishLogin()
+}
+
+func (client *testClient) startLogin(username string) {
+	client.t.Helper()
+	client.send(&packets.PacketHandshakingStart{
+		ProtocolVersion: int32(client.proto),
+		ServerAddress:   "localhost",
+		ServerPort:      25565,
+		NextState:       2,
+	})
+	client.state = protocol.Login
+	client.send(&packets.PacketLoginInStart{Username: username, UniqueID: offlineUniqueID(username)})
+}
+
+func (client *testClient) finishLogin() {
+	client.t.Helper()
+	compression := client.expect(&packets.PacketLoginOutCompression{}).(*packets.PacketLoginOutCompression)
+	client.threshold = int(compression.Threshold)
+	client.expect(&packets.PacketLoginOutSuccess{})
+
+	if client.proto >= protocol.V1_20_2 {
+		client.send(&packets.PacketLoginInAcknowledged{})
+		client.state = protocol.Configuration
+		client.finishConfiguration()
+	}
+	client.state = protocol.Play
+}
+
+// finishConfiguration expects the registries before the finish and acknowledges it,
+// the join game must be the first packet after that
+func (client *testClient) finishConfiguration() {
+	client.t.Helper()
+	client.expect(&packets.PacketConfigurationOutRegistryData{})
+	client.expect(&packets.PacketConfigurationOutFinish{})
+	client.send(&packets.PacketConfigurationInFinish{})
+
+	client.state = protocol.Play
+	if packet := client.next(); reflect.TypeOf(packet) != reflect.TypeOf(&packets.PacketPlayOutJoinGame{}) {
+		client.t.Fatalf("first packet after configuration is %T, want the join game", packet)
+	}
+}
+
+func BenchmarkEncodePacket(b *testing.B) {
+	key := frameKey{
+		protocol:  protocol.V1_16_4,
+		state:     protocol.Play,
+		threshold: -1,
+		table:     packets.Lookup(protocol.V1_16_4, protocol.Play, protocol.ClientBound),
+	}
+	packet := &packets.PacketPlayOutKeepAlive{KeepAliveID: 42}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := encodePacket(key, packet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCheckProtocol(t *testing.T) {
+	tests := []struct {
+		proto   protocol.Protocol
+		allowed bool
+	}{
+		{protocol.V1_8, true},
+		{protocol.V1_16_4, true},
+		{protocol.V1_17, true},
+		{protocol.V1_20_2, true},
+		{protocol.Protocol(5), false},
+		{protocol.HighestProtocol + 1, false},
+	}
+	for _, test := range tests {
+		if got := checkProtocol(test.proto) == nil; got != test.allowed {
+			t.Errorf("checkProtocol(%d) allowed = %v, want %v", test.proto, got, test.allowed)
+		}
+	}
+}
+
+func TestConfiguration(t *testing.T) {
+	server := newTestServer(t, Config{Compression: CompressionConf{Threshold: 256}})
+	client := connect(t, server, protocol.V1_20_2)
+	client.login("Tester")
+
+	player := server.GetPlayerByName("Tester")
+	if player == nil {
+		t.Fatal("player isn't online after joining")
+	}
+
+	if err := player.Reconfigure(); err != nil {
+		t.Fatal(err)
+	}
+	client.expect(&packets.PacketPlayOutStartConfiguration{})
+	client.send(&packets.PacketPlayInConfigurationAcknowledged{})
+	client.state = protocol.Configuration
+	client.finishConfiguration()
+
+	if server.GetPlayerByName("Tester") != player {
+		t.Error("player isn't online after reconfiguring")
+	}
+}
+
+func TestReconfigureUnsupported(t *testing.T) {
+	server := newTestServer(t, Config{Compression: CompressionConf{Threshold: -1}})
+	client := connect(t, server, protocol.V1_20)
+	client.login("Tester")
+
+	if err := server.GetPlayerByName("Tester").Reconfigure(); err != ErrConfigurationUnsupported {
+		t.Errorf("Reconfigure() error = %v, want %v", err, ErrConfigurationUnsupported)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bufio"
+	"github.com/r4g3baby/mcserver/pkg/command"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/permission"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"io"
+	"strings"
+)
+
+type (
+	// Console runs commands read line by line, replying through the logger
+	Console interface {
+		command.Source
+		permission.Permissible
+		Run(reader io.Reader) error
+	}
+
+	console struct {
+		server Server
+	}
+)
+
+func (console *console) GetName() string {
+	return "Server"
+}
+
+func (console *console) SendMessage(message []chat.Component) error {
+	log.Log.Info(chat.StripColors(chat.ToLegacyText(message)))
+	return nil
+}
+
+func (console *console) HasPermission(_ string) bool {
+	return true
+}
+
+func (console *console) IsOperator() bool {
+	return true
+}
+
+// Run blocks until the reader is exhausted, like when stdin is closed
+func (console *console) Run(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "/")
+		if line == "" {
+			continue
+		}
+		console.server.DispatchCommand(console, line)
+	}
+	return scanner.Err()
+}
+
+func NewConsole(server Server) Console {
+	return &console{server: server}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/blocks"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/protocol/particles"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+)
+
+const (
+	// Vanilla only sends particles to players within this distance
+	ParticleRange             = 32
+	LongDistanceParticleRange = 512
+	// Sounds can be heard from this distance, multiplied by the volume when it's greater than 1
+	SoundRange = 16
+)
+
+type ParticleEffect struct {
+	Particle     string
+	Position     Position
+	Offset       Position
+	Speed        float32
+	Count        int32
+	LongDistance bool
+	// Color and Size are used by the dust particle
+	Color *chat.Color
+	Size  float32
+	// Block is used by the block, block_marker and falling_dust particles
+	Block string
+}
+
+func (effect ParticleEffect) getRange() float64 {
+	if effect.LongDistance {
+		return LongDistanceParticleRange
+	}
+	return ParticleRange
+}
+
+// toPacket builds the particle packet for the given protocol,
+// returning nil when the particle doesn't exist in that version.
+func (effect ParticleEffect) toPacket(proto protocol.Protocol) (*packets.PacketPlayOutParticle, error) {
+	id, ok := particles.GetParticleID(effect.Particle, proto)
+	if !ok {
+		return nil, nil
+	}
+
+	packet := &packets.PacketPlayOutParticle{
+		ParticleID:   int32(id),
+		LongDistance: effect.LongDistance,
+		X:            effect.Position.X,
+		Y:            effect.Position.Y,
+		Z:            effect.Position.Z,
+		OffsetX:      float32(effect.Offset.X),
+		OffsetY:      float32(effect.Offset.Y),
+		OffsetZ:      float32(effect.Offset.Z),
+		ParticleData: effect.Speed,
+		Count:        effect.Count,
+	}
+
+	switch effect.Particle {
+	case "minecraft:dust":
+		r, g, b := float32(1), float32(0), float32(0)
+		if effect.Color != nil {
+			red, green, blue := effect.Color.RGB()
+			r, g, b = float32(red)/255, float32(green)/255, float32(blue)/255
+		}
+
+		if proto < protocol.V1_13 {
+			// Legacy clients read the color from the offsets, but only when the count is 0,
+			// and treat a red value of 0 as the default red
+			if r == 0 {
+				r = 0.001
+			}
+			packet.OffsetX, packet.OffsetY, packet.OffsetZ = r, g, b
+			packet.ParticleData = 1
+			packet.Count = 0
+			return packet, nil
+		}
+
+		size := effect.Size
+		if size == 0 {
+			size = 1
+		}
+
+		data := bytes.NewBuffer(nil)
+		for _, value := range []float32{r, g, b, size} {
+			if err := data.WriteFloat32(value); err != nil {
+				return nil, err
+			}
+		}
+		packet.Data = data.Bytes()
+	case "minecraft:block", "minecraft:block_marker", "minecraft:falling_dust":
+		block := effect.Block
+		if block == "" {
+			block = "minecraft:stone"
+		}
+
+		data := bytes.NewBuffer(nil)
+		if err := data.WriteVarInt(int32(blocks.GetBlockID(block, proto))); err != nil {
+			return nil, err
+		}
+		packet.Data = data.Bytes()
+	}
+
+	return packet, nil
+}
+
+func getSoundRange(volume float32) float64 {
+	if volume > 1 {
+		return SoundRange * float64(volume)
+	}
+	return SoundRange
+}
@@ -1,13 +1,19 @@
 package server
 
 import (
+	"context"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
 	"sync"
+	"time"
 )
 
 var (
-	OnPacketReadEvent  = "onPacketRead"
-	OnPacketWriteEvent = "onPacketWrite"
+	OnPacketReadEvent     = "onPacketRead"
+	OnPacketWriteEvent    = "onPacketWrite"
+	OnPlayerPreLoginEvent = "onPlayerPreLogin"
+	OnPlayerQuitEvent     = "onPlayerQuit"
 )
 
 type (
@@ -19,6 +25,38 @@ type (
 		IsCancelled() bool
 	}
 
+	// PlayerQuitEvent is fired after the player was removed, its connection is already closed
+	PlayerQuitEvent interface {
+		GetPlayer() Player
+		GetReason() []chat.Component
+	}
+
+	// PreLoginEvent is fired once the client sent its username, before the player is created.
+	// Handlers can ask the client login queries and hold back the login success until they are done
+	PreLoginEvent interface {
+		GetConnection() Connection
+		// Delay must be called before the handler returns, the login continues once every done was called
+		// or the player is disconnected if that takes longer than the pre-login timeout
+		Delay() (done func())
+		Disallow(reason []chat.Component)
+		GetDisallowReason() []chat.Component
+		wait(ctx context.Context, timeout time.Duration) error
+	}
+
+	preLoginEvent struct {
+		connection Connection
+
+		mutex    sync.RWMutex
+		reason   []chat.Component
+		delays   int
+		released chan struct{}
+	}
+
+	playerQuitEvent struct {
+		player Player
+		reason []chat.Component
+	}
+
 	packetEvent struct {
 		connection Connection
 		player     Player
@@ -61,3 +99,85 @@ func NewPacketEvent(connection Connection, player Player, packet protocol.Packet
 		cancelled:  false,
 	}
 }
+
+func (e *preLoginEvent) GetConnection() Connection {
+	return e.connection
+}
+
+func (e *preLoginEvent) Delay() func() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.delays == 0 {
+		e.released = make(chan struct{})
+	}
+	e.delays++
+
+	var once sync.Once
+	return func() {
+		once.Do(e.release)
+	}
+}
+
+// release closes the released channel once the last delay is done
+func (e *preLoginEvent) release() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.delays--; e.delays == 0 {
+		close(e.released)
+	}
+}
+
+func (e *preLoginEvent) Disallow(reason []chat.Component) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.reason = reason
+}
+
+func (e *preLoginEvent) GetDisallowReason() []chat.Component {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.reason
+}
+
+// wait blocks until every delay is done, the timeout passes or the context is cancelled
+func (e *preLoginEvent) wait(ctx context.Context, timeout time.Duration) error {
+	e.mutex.RLock()
+	delays, released := e.delays, e.released
+	e.mutex.RUnlock()
+	if delays == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-released:
+		return nil
+	case <-timer.C:
+		return ErrPreLoginTimeout
+	case <-ctx.Done():
+		return net.ErrClosed
+	}
+}
+
+func NewPreLoginEvent(connection Connection) PreLoginEvent {
+	return &preLoginEvent{
+		connection: connection,
+	}
+}
+
+func (e *playerQuitEvent) GetPlayer() Player {
+	return e.player
+}
+
+func (e *playerQuitEvent) GetReason() []chat.Component {
+	return e.reason
+}
+
+func NewPlayerQuitEvent(player Player, reason []chat.Component) PlayerQuitEvent {
+	return &playerQuitEvent{
+		player: player,
+		reason: reason,
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/util/pools"
+	"io"
+)
+
+const (
+	// MaxPacketSize is the largest frame the client can send, the most a 3 byte VarInt can hold
+	MaxPacketSize = 2097151
+	// MaxUncompressedPacketSize bounds what a compressed frame may inflate to
+	MaxUncompressedPacketSize = 8388608
+
+	// initialFrameSize is the most allocated for a frame before any of it arrived
+	initialFrameSize = 4096
+)
+
+var (
+	ErrVarIntTooBig        = errors.New("VarInt too big")
+	ErrInvalidPacketLength = errors.New("received invalid packet length")
+	ErrPacketTooLarge      = errors.New("received packet is too large")
+	ErrInvalidDataLength   = errors.New("received invalid uncompressed data length")
+)
+
+// readFrame reads a single packet frame, decompressing it when the threshold isn't negative.
+// It returns the packet id followed by its data and the number of bytes read from the wire
+func readFrame(reader *bufio.Reader, threshold int) ([]byte, int, error) {
+	length, size, err := readVarInt(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if length < 1 {
+		return nil, 0, ErrInvalidPacketLength
+	} else if length > MaxPacketSize {
+		return nil, 0, ErrPacketTooLarge
+	}
+
+	payload, err := readLimited(reader, int(length))
+	if err != nil {
+		return nil, 0, err
+	}
+	wireLength := size + int(length)
+
+	if threshold < 0 {
+		return payload, wireLength, nil
+	}
+
+	compressed := bytes.NewReader(payload)
+	dataLength, size, err := readVarInt(compressed)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if dataLength == 0 {
+		if len(payload) == size {
+			return nil, 0, ErrInvalidPacketLength
+		}
+		return payload[size:], wireLength, nil
+	}
+
+	// Vanilla never compresses packets below the threshold, so neither can the client
+	if dataLength < 0 || int(dataLength) < threshold {
+		return nil, 0, fmt.Errorf("%w: %d is below the threshold of %d", ErrInvalidDataLength, dataLength, threshold)
+	} else if dataLength > MaxUncompressedPacketSize {
+		return nil, 0, ErrPacketTooLarge
+	}
+
+	data, err := inflate(compressed, int(dataLength))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, wireLength, nil
+}
+
+// inflate never reads more than the declared length out of the zlib stream,
+// so a small frame can't expand into an unbounded amount of memory
+func inflate(compressed io.Reader, dataLength int) ([]byte, error) {
+	zlibReader, err := pools.Zlib.GetReader(compressed)
+	if err != nil {
+		return nil, err
+	}
+	defer pools.Zlib.PutReader(zlibReader)
+
+	data, err := readLimited(zlibReader, dataLength)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: inflated to less than %d bytes", ErrInvalidDataLength, dataLength)
+		}
+		return nil, err
+	}
+
+	if n, _ := zlibReader.Read(make([]byte, 1)); n > 0 {
+		return nil, fmt.Errorf("%w: inflated to more than %d bytes", ErrInvalidDataLength, dataLength)
+	}
+	return data, nil
+}
+
+// readLimited reads exactly length bytes like io.ReadFull, but the buffer only grows as the
+// data arrives so a declared length that is never sent doesn't cost its size in memory
+func readLimited(reader io.Reader, length int) ([]byte, error) {
+	size := length
+	if size > initialFrameSize {
+		size = initialFrameSize
+	}
+
+	data := make([]byte, 0, size)
+	limited := io.LimitReader(reader, int64(length))
+	for {
+		if len(data) == cap(data) && cap(data) < length {
+			size := 2 * cap(data)
+			if size > length {
+				size = length
+			}
+
+			grown := make([]byte, len(data), size)
+			copy(grown, data)
+			data = grown
+		}
+
+		n, err := limited.Read(data[len(data):cap(data)])
+		data = data[:len(data)+n]
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case len(data) == length:
+		return data, nil
+	case len(data) == 0:
+		return nil, io.EOF
+	default:
+		return nil, io.ErrUnexpectedEOF
+	}
+}
+
+// readVarInt returns the value and the number of bytes it took
+func readVarInt(reader io.ByteReader) (int32, int, error) {
+	var result uint32
+	for numRead := 0; numRead < 5; numRead++ {
+		read, err := reader.ReadByte()
+		if err != nil {
+			if numRead > 0 && errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, 0, err
+		}
+
+		result |= uint32(read&0x7F) << (7 * numRead)
+		if (read & 0x80) != 0x80 {
+			return int32(result), numRead + 1, nil
+		}
+	}
+	return 0, 0, ErrVarIntTooBig
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"bufio"
+	stdbytes "bytes"
+	"errors"
+	"github.com/klauspost/compress/zlib"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"io"
+	"runtime"
+	"testing"
+)
+
+const testThreshold = 256
+
+func frame(t testing.TB, payload []byte) []byte {
+	buffer := bytes.NewBuffer(nil)
+	if err := buffer.WriteVarInt(int32(len(payload))); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = buffer.Write(payload)
+	return buffer.Bytes()
+}
+
+// compressedFrame declares dataLength, which doesn't have to match what data inflates to
+func compressedFrame(t testing.TB, dataLength int32, data []byte) []byte {
+	buffer := bytes.NewBuffer(nil)
+	if err := buffer.WriteVarInt(dataLength); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := zlib.NewWriter(buffer)
+	if _, err := writer.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return frame(t, buffer.Bytes())
+}
+
+func TestReadFrame(t *testing.T) {
+	packet := stdbytes.Repeat([]byte{0x01}, 512)
+
+	tests := []struct {
+		name      string
+		input     []byte
+		threshold int
+		want      []byte
+		err       error
+	}{
+		{"uncompressed", frame(t, []byte{0x00, 0x2A}), -1, []byte{0x00, 0x2A}, nil},
+		{"below threshold", frame(t, []byte{0x00, 0x00, 0x2A}), testThreshold, []byte{0x00, 0x2A}, nil},
+		{"compressed", compressedFrame(t, int32(len(packet)), packet), testThreshold, packet, nil},
+		{"empty frame", []byte{0x00}, -1, nil, ErrInvalidPacketLength},
+		{"empty uncompressed data", frame(t, []byte{0x00}), testThreshold, nil, ErrInvalidPacketLength},
+		{"negative length", []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F}, -1, nil, ErrInvalidPacketLength},
+		{"too large", []byte{0x80, 0x80, 0x80, 0x01}, -1, nil, ErrPacketTooLarge},
+		{"VarInt too big", []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, -1, nil, ErrVarIntTooBig},
+		{"truncated", []byte{0x05, 0x00}, -1, nil, io.ErrUnexpectedEOF},
+		{"compressed below threshold", compressedFrame(t, 16, packet[:16]), testThreshold, nil, ErrInvalidDataLength},
+		{"declared too large", compressedFrame(t, MaxUncompressedPacketSize+1, packet), testThreshold, nil, ErrPacketTooLarge},
+		{"inflates to less", compressedFrame(t, int32(len(packet)+1), packet), testThreshold, nil, ErrInvalidDataLength},
+		{"inflates to more", compressedFrame(t, int32(len(packet)-1), packet), testThreshold, nil, ErrInvalidDataLength},
+		{"zlib bomb", compressedFrame(t, testThreshold, make([]byte, 64*1024*1024)), testThreshold, nil, ErrInvalidDataLength},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, wireLength, err := readFrame(bufio.NewReader(stdbytes.NewReader(test.input)), test.threshold)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("readFrame() error = %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !stdbytes.Equal(got, test.want) {
+				t.Errorf("readFrame() = %v, want %v", got, test.want)
+			}
+			if wireLength != len(test.input) {
+				t.Errorf("readFrame() wireLength = %d, want %d", wireLength, len(test.input))
+			}
+		})
+	}
+}
+
+func TestReadFrameAllocations(t *testing.T) {
+	// A few bytes declaring the largest sizes must not allocate them before the data arrives
+	tests := []struct {
+		name      string
+		input     []byte
+		threshold int
+	}{
+		{"length", []byte{0xFF, 0xFF, 0x7F, 0x00}, -1},
+		{"data length", compressedFrame(t, MaxUncompressedPacketSize, []byte{0x01}), testThreshold},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			if _, _, err := readFrame(bufio.NewReader(stdbytes.NewReader(test.input)), test.threshold); err == nil {
+				t.Fatal("readFrame() error = nil, want the frame to be rejected")
+			}
+			runtime.ReadMemStats(&after)
+
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 256*1024 {
+				t.Errorf("readFrame() allocated %d bytes", allocated)
+			}
+		})
+	}
+}
+
+func FuzzReadFrame(f *testing.F) {
+	packet := stdbytes.Repeat([]byte{0x01}, 512)
+	f.Add(frame(f, []byte{0x00, 0x2A}), -1)
+	f.Add(frame(f, []byte{0x00, 0x00, 0x2A}), testThreshold)
+	f.Add(compressedFrame(f, int32(len(packet)), packet), testThreshold)
+	f.Add(compressedFrame(f, int32(len(packet)+1), packet), testThreshold)
+	f.Add(compressedFrame(f, testThreshold, make([]byte, 1024*1024)), testThreshold)
+	f.Add([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, -1)
+
+	f.Fuzz(func(t *testing.T, input []byte, threshold int) {
+		reader := bufio.NewReader(stdbytes.NewReader(input))
+		for {
+			data, wireLength, err := readFrame(reader, threshold)
+			if err != nil {
+				return
+			}
+
+			if len(data) == 0 || len(data) > MaxUncompressedPacketSize {
+				t.Fatalf("readFrame() returned %d bytes", len(data))
+			}
+			if wireLength > len(input) {
+				t.Fatalf("readFrame() wireLength = %d, input is %d bytes", wireLength, len(input))
+			}
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	ThrottledRejection       = "throttled"
+	HandshakingRejection     = "too_many_handshaking"
+	LoginRejection           = "too_many_login"
+	LoginRateRejection       = "login_rate"
+	PreLoginTimeoutRejection = "pre_login_timeout"
+)
+
+var rejectionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "connections_rejected_total",
+	Help:      "Number of connections rejected by the connection limits, by reason.",
+}, []string{"reason"})
+
+// connectionLimiter enforces the configured limits, each of them is disabled when set to zero
+type connectionLimiter struct {
+	config LimitsConf
+
+	mutex       sync.Mutex
+	connections map[string]time.Time
+	lastCleanup time.Time
+	handshaking int
+	login       int
+	tokens      float64
+	lastRefill  time.Time
+}
+
+// allowConnection is checked for every accepted socket, before anything is read from it
+func (limiter *connectionLimiter) allowConnection(addr net.Addr) bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if max := limiter.config.MaxHandshaking; max > 0 && limiter.handshaking >= max {
+		limiter.reject(addr, HandshakingRejection)
+		return false
+	}
+	return true
+}
+
+// allowLogin is checked once a client asks to log in, it returns why it can't
+func (limiter *connectionLimiter) allowLogin(addr net.Addr) []chat.Component {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if throttle := limiter.config.ConnectionThrottle; throttle > 0 {
+		if host, _, err := net.SplitHostPort(addr.String()); err == nil {
+			// Loopback is exempt so local proxies and health checks aren't throttled
+			if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+				now := time.Now()
+				limiter.cleanup(now)
+
+				last, ok := limiter.connections[host]
+				limiter.connections[host] = now
+				if ok && now.Sub(last) < throttle {
+					limiter.reject(addr, ThrottledRejection)
+					return coloredText("Connection throttled! Please wait before reconnecting.", &chat.Red)
+				}
+			}
+		}
+	}
+
+	if max := limiter.config.MaxLogin; max > 0 && limiter.login >= max {
+		limiter.reject(addr, LoginRejection)
+		return coloredText("The server is busy, please try again later.", &chat.Red)
+	}
+
+	if rate := limiter.config.LoginRate; rate > 0 {
+		now := time.Now()
+		burst := float64(limiter.config.LoginBurst)
+		if burst < 1 {
+			burst = 1
+		}
+
+		limiter.tokens += now.Sub(limiter.lastRefill).Seconds() * rate
+		if limiter.tokens > burst {
+			limiter.tokens = burst
+		}
+		limiter.lastRefill = now
+
+		if limiter.tokens < 1 {
+			limiter.reject(addr, LoginRateRejection)
+			return coloredText("Too many players are logging in, please try again later.", &chat.Red)
+		}
+		limiter.tokens--
+	}
+	return nil
+}
+
+// enter and leave keep count of the connections in each limited state
+func (limiter *connectionLimiter) enter(state protocol.State) {
+	limiter.count(state, 1)
+}
+
+func (limiter *connectionLimiter) leave(state protocol.State) {
+	limiter.count(state, -1)
+}
+
+func (limiter *connectionLimiter) count(state protocol.State, delta int) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	switch state {
+	case protocol.Handshaking:
+		limiter.handshaking += delta
+	case protocol.Login:
+		limiter.login += delta
+	}
+}
+
+func (limiter *connectionLimiter) reject(addr net.Addr, reason string) {
+	rejectionsCounter.WithLabelValues(reason).Inc()
+	log.Log.WithValues(
+		"connection", addr,
+		"reason", reason,
+	).Info("rejected connection")
+}
+
+// cleanup forgets addresses that are no longer throttled, must hold the mutex
+func (limiter *connectionLimiter) cleanup(now time.Time) {
+	if now.Sub(limiter.lastCleanup) < limiter.config.ConnectionThrottle {
+		return
+	}
+	limiter.lastCleanup = now
+
+	for host, last := range limiter.connections {
+		if now.Sub(last) >= limiter.config.ConnectionThrottle {
+			delete(limiter.connections, host)
+		}
+	}
+}
+
+func newConnectionLimiter(config LimitsConf) *connectionLimiter {
+	return &connectionLimiter{
+		config:      config,
+		connections: make(map[string]time.Time),
+		tokens:      float64(config.LoginBurst),
+		lastRefill:  time.Now(),
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestAllowLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   LimitsConf
+		logins   int
+		ips      []string
+		rejected []bool
+	}{
+		{"throttled", LimitsConf{ConnectionThrottle: time.Minute}, 0,
+			[]string{"203.0.113.1", "203.0.113.1", "203.0.113.2"}, []bool{false, true, false}},
+		{"loopback", LimitsConf{ConnectionThrottle: time.Minute}, 0,
+			[]string{"127.0.0.1", "127.0.0.1", "::1", "::1"}, []bool{false, false, false, false}},
+		{"below max login", LimitsConf{MaxLogin: 2}, 1,
+			[]string{"203.0.113.1"}, []bool{false}},
+		{"max login", LimitsConf{MaxLogin: 2}, 2,
+			[]string{"203.0.113.1", "203.0.113.2"}, []bool{true, true}},
+		{"login rate", LimitsConf{LoginRate: 0.001, LoginBurst: 2}, 0,
+			[]string{"203.0.113.1", "203.0.113.2", "203.0.113.3"}, []bool{false, false, true}},
+		{"disabled", LimitsConf{}, 100,
+			[]string{"203.0.113.1", "203.0.113.1"}, []bool{false, false}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := newConnectionLimiter(test.config)
+			for i := 0; i < test.logins; i++ {
+				limiter.enter(protocol.Login)
+			}
+
+			for i, ip := range test.ips {
+				addr := &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000 + i}
+				if rejected := limiter.allowLogin(addr) != nil; rejected != test.rejected[i] {
+					t.Errorf("allowLogin(%s) rejected = %t, want %t", addr, rejected, test.rejected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAllowLoginAfterLeave(t *testing.T) {
+	limiter := newConnectionLimiter(LimitsConf{MaxLogin: 1})
+	addr := &net.TCPAddr{IP: net.ParseIP("203.0.113.1"), Port: 50000}
+
+	limiter.enter(protocol.Login)
+	if limiter.allowLogin(addr) == nil {
+		t.Fatal("allowLogin() allowed a login above MaxLogin")
+	}
+
+	limiter.leave(protocol.Login)
+	if reason := limiter.allowLogin(addr); reason != nil {
+		t.Errorf("allowLogin() = %v after the other login left", reason)
+	}
+}
+
+func TestAllowLoginRefill(t *testing.T) {
+	tests := []struct {
+		elapsed time.Duration
+		allowed int
+	}{
+		{0, 0},
+		{500 * time.Millisecond, 0},
+		{1500 * time.Millisecond, 1},
+		{2500 * time.Millisecond, 2},
+		{time.Hour, 3},
+	}
+	for _, test := range tests {
+		limiter := newConnectionLimiter(LimitsConf{LoginRate: 1, LoginBurst: 3})
+		limiter.tokens = 0
+		limiter.lastRefill = time.Now().Add(-test.elapsed)
+
+		allowed := 0
+		for i := 0; i < 5; i++ {
+			addr := &net.TCPAddr{IP: net.ParseIP("203.0.113.1"), Port: 50000 + i}
+			if limiter.allowLogin(addr) == nil {
+				allowed++
+			}
+		}
+		if allowed != test.allowed {
+			t.Errorf("%v: allowLogin() allowed %d logins, want %d", test.elapsed, allowed, test.allowed)
+		}
+	}
+}
@@ -0,0 +1,442 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/util"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	WhitelistFile     = "whitelist.json"
+	BannedPlayersFile = "banned-players.json"
+	BannedIPsFile     = "banned-ips.json"
+
+	// Vanilla stores dates with this layout and uses "forever" for bans that never expire
+	banTimeLayout = "2006-01-02 15:04:05 -0700"
+	banForever    = "forever"
+
+	DefaultBanReason = "Banned by an operator."
+)
+
+type (
+	Whitelist interface {
+		IsEnabled() bool
+		SetEnabled(enabled bool)
+		Add(entry WhitelistEntry) error
+		Remove(uniqueID uuid.UUID) error
+		Contains(uniqueID uuid.UUID) bool
+		GetEntries() []WhitelistEntry
+		Reload() error
+		reloadIfChanged() error
+	}
+
+	WhitelistEntry struct {
+		UniqueID uuid.UUID `json:"uuid"`
+		Name     string    `json:"name"`
+	}
+
+	// BanList holds player bans keyed by uuid or ip bans keyed by address
+	BanList interface {
+		Add(entry BanEntry) error
+		Remove(target string) error
+		GetBan(target string) *BanEntry
+		GetEntries() []BanEntry
+		Reload() error
+		reloadIfChanged() error
+	}
+
+	BanEntry struct {
+		Target  string
+		Name    string
+		Created time.Time
+		Source  string
+		Expires time.Time
+		Reason  string
+	}
+
+	whitelist struct {
+		file    listFile
+		enabled bool
+		entries map[uuid.UUID]WhitelistEntry
+	}
+
+	banList struct {
+		file    listFile
+		ip      bool
+		entries map[string]BanEntry
+	}
+
+	// listFile tracks the modification time of a json list so external edits can be picked up
+	listFile struct {
+		mutex    sync.RWMutex
+		fileName string
+		modTime  time.Time
+	}
+
+	banEntryJSON struct {
+		UniqueID string `json:"uuid,omitempty"`
+		Name     string `json:"name,omitempty"`
+		IP       string `json:"ip,omitempty"`
+		Created  string `json:"created"`
+		Source   string `json:"source"`
+		Expires  string `json:"expires"`
+		Reason   string `json:"reason"`
+	}
+)
+
+func (list *whitelist) IsEnabled() bool {
+	list.file.mutex.RLock()
+	defer list.file.mutex.RUnlock()
+	return list.enabled
+}
+
+func (list *whitelist) SetEnabled(enabled bool) {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	list.enabled = enabled
+}
+
+func (list *whitelist) Add(entry WhitelistEntry) error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	list.entries[entry.UniqueID] = entry
+	return list.save()
+}
+
+func (list *whitelist) Remove(uniqueID uuid.UUID) error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	delete(list.entries, uniqueID)
+	return list.save()
+}
+
+func (list *whitelist) Contains(uniqueID uuid.UUID) bool {
+	list.file.mutex.RLock()
+	defer list.file.mutex.RUnlock()
+	_, ok := list.entries[uniqueID]
+	return ok
+}
+
+func (list *whitelist) GetEntries() []WhitelistEntry {
+	list.file.mutex.RLock()
+	defer list.file.mutex.RUnlock()
+
+	entries := make([]WhitelistEntry, 0, len(list.entries))
+	for _, entry := range list.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+	return entries
+}
+
+func (list *whitelist) Reload() error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	return list.load(true)
+}
+
+func (list *whitelist) reloadIfChanged() error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	return list.load(false)
+}
+
+func (list *whitelist) load(force bool) error {
+	var entries []WhitelistEntry
+	if loaded, err := list.file.read(&entries, force); err != nil || !loaded {
+		return err
+	}
+
+	list.entries = make(map[uuid.UUID]WhitelistEntry, len(entries))
+	for _, entry := range entries {
+		list.entries[entry.UniqueID] = entry
+	}
+	return nil
+}
+
+func (list *whitelist) save() error {
+	entries := make([]WhitelistEntry, 0, len(list.entries))
+	for _, entry := range list.entries {
+		entries = append(entries, entry)
+	}
+	return list.file.write(entries)
+}
+
+func (list *banList) Add(entry BanEntry) error {
+	if entry.Created.IsZero() {
+		entry.Created = time.Now()
+	}
+	if entry.Source == "" {
+		entry.Source = "Server"
+	}
+	if entry.Reason == "" {
+		entry.Reason = DefaultBanReason
+	}
+
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	list.entries[list.key(entry.Target)] = entry
+	return list.save()
+}
+
+func (list *banList) Remove(target string) error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	delete(list.entries, list.key(target))
+	return list.save()
+}
+
+func (list *banList) GetBan(target string) *BanEntry {
+	list.file.mutex.RLock()
+	defer list.file.mutex.RUnlock()
+	if entry, ok := list.entries[list.key(target)]; ok && !entry.IsExpired() {
+		return &entry
+	}
+	return nil
+}
+
+func (list *banList) GetEntries() []BanEntry {
+	list.file.mutex.RLock()
+	defer list.file.mutex.RUnlock()
+
+	entries := make([]BanEntry, 0, len(list.entries))
+	for _, entry := range list.entries {
+		if !entry.IsExpired() {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Created.Before(entries[j].Created)
+	})
+	return entries
+}
+
+func (list *banList) Reload() error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	return list.load(true)
+}
+
+func (list *banList) reloadIfChanged() error {
+	list.file.mutex.Lock()
+	defer list.file.mutex.Unlock()
+	return list.load(false)
+}
+
+func (list *banList) key(target string) string {
+	return strings.ToLower(target)
+}
+
+func (list *banList) load(force bool) error {
+	var entries []banEntryJSON
+	if loaded, err := list.file.read(&entries, force); err != nil || !loaded {
+		return err
+	}
+
+	list.entries = make(map[string]BanEntry, len(entries))
+	for _, data := range entries {
+		entry := BanEntry{Name: data.Name, Source: data.Source, Reason: data.Reason}
+		if list.ip {
+			entry.Target = data.IP
+		} else {
+			entry.Target = data.UniqueID
+		}
+
+		if created, err := time.Parse(banTimeLayout, data.Created); err == nil {
+			entry.Created = created
+		}
+		if data.Expires != banForever {
+			if expires, err := time.Parse(banTimeLayout, data.Expires); err == nil {
+				entry.Expires = expires
+			}
+		}
+		list.entries[list.key(entry.Target)] = entry
+	}
+	return nil
+}
+
+func (list *banList) save() error {
+	entries := make([]banEntryJSON, 0, len(list.entries))
+	for _, entry := range list.entries {
+		data := banEntryJSON{
+			Name:    entry.Name,
+			Created: entry.Created.Format(banTimeLayout),
+			Source:  entry.Source,
+			Expires: banForever,
+			Reason:  entry.Reason,
+		}
+		if list.ip {
+			data.IP = entry.Target
+		} else {
+			data.UniqueID = entry.Target
+		}
+		if !entry.Expires.IsZero() {
+			data.Expires = entry.Expires.Format(banTimeLayout)
+		}
+		entries = append(entries, data)
+	}
+	return list.file.write(entries)
+}
+
+func (entry BanEntry) IsExpired() bool {
+	return !entry.Expires.IsZero() && time.Now().After(entry.Expires)
+}
+
+// read decodes the file into value when it changed since the last read or write,
+// a missing file counts as an empty list, must hold the mutex
+func (file *listFile) read(value interface{}, force bool) (bool, error) {
+	info, err := os.Stat(file.fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !force && info.ModTime().Equal(file.modTime) {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(file.fileName)
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", file.fileName, err)
+	}
+	file.modTime = info.ModTime()
+	return true, nil
+}
+
+// write must hold the mutex
+func (file *listFile) write(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(file.fileName, data, 0644); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(file.fileName); err == nil {
+		file.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// offlineUniqueID returns the uuid offline mode players with this name log in with
+func offlineUniqueID(name string) uuid.UUID {
+	return util.NameUUIDFromBytes([]byte("OfflinePlayer:" + name))
+}
+
+func banMessage(entry *BanEntry, ip bool) []chat.Component {
+	text := "You are banned from this server."
+	if ip {
+		text = "Your IP address is banned from this server."
+	}
+
+	message := coloredText(text+"\n", &chat.Red)
+	message = append(message, coloredText("Reason: ", &chat.Gray)...)
+	message = append(message, coloredText(entry.Reason, &chat.White)...)
+	if !entry.Expires.IsZero() {
+		message = append(message, coloredText("\nYour ban will be removed on ", &chat.Gray)...)
+		message = append(message, coloredText(entry.Expires.Format(banTimeLayout), &chat.White)...)
+	}
+	return message
+}
+
+// BanPlayer bans the player with this name, kicking them if online
+func BanPlayer(server Server, name, source, reason string, expires time.Time) (BanEntry, error) {
+	entry := BanEntry{
+		Target:  offlineUniqueID(name).String(),
+		Name:    name,
+		Source:  source,
+		Reason:  reason,
+		Expires: expires,
+	}
+
+	player := server.GetPlayerByName(name)
+	if player != nil {
+		entry.Target = player.GetUniqueID().String()
+		entry.Name = player.GetUsername()
+	}
+
+	if err := server.GetBanList().Add(entry); err != nil {
+		return entry, err
+	}
+
+	ban := server.GetBanList().GetBan(entry.Target)
+	if ban == nil {
+		return entry, nil
+	}
+	entry = *ban
+
+	if player != nil {
+		if err := player.Kick(banMessage(ban, false)); err != nil {
+			return entry, err
+		}
+	}
+	return entry, nil
+}
+
+// BanIP bans the address, kicking every player connected from it
+func BanIP(server Server, ip, source, reason string, expires time.Time) (BanEntry, error) {
+	entry := BanEntry{
+		Target:  ip,
+		Source:  source,
+		Reason:  reason,
+		Expires: expires,
+	}
+
+	if err := server.GetIPBanList().Add(entry); err != nil {
+		return entry, err
+	}
+
+	ban := server.GetIPBanList().GetBan(ip)
+	if ban == nil {
+		return entry, nil
+	}
+	entry = *ban
+
+	for _, player := range server.GetPlayers() {
+		if host, _, err := net.SplitHostPort(player.GetAddress().String()); err == nil && host == ip {
+			if err := player.Kick(banMessage(ban, true)); err != nil {
+				return entry, err
+			}
+		}
+	}
+	return entry, nil
+}
+
+// NewWhitelist always returns a usable list, even when the existing file couldn't be read
+func NewWhitelist(fileName string, enabled bool) (Whitelist, error) {
+	list := &whitelist{
+		file:    listFile{fileName: fileName},
+		enabled: enabled,
+		entries: make(map[uuid.UUID]WhitelistEntry),
+	}
+	return list, list.Reload()
+}
+
+// NewBanList always returns a usable list, even when the existing file couldn't be read
+func NewBanList(fileName string, ip bool) (BanList, error) {
+	list := &banList{
+		file:    listFile{fileName: fileName},
+		ip:      ip,
+		entries: make(map[string]BanEntry),
+	}
+	return list, list.Reload()
+}
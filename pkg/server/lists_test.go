@@ -0,0 +1,111 @@
+package server
+
+import (
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBanListExpiry(t *testing.T) {
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name    string
+		expires string
+		banned  bool
+		want    time.Time
+	}{
+		{"forever", banForever, true, time.Time{}},
+		{"future", future.Format(banTimeLayout), true, future},
+		{"expired", "2000-01-01 00:00:00 +0000", false, time.Time{}},
+		{"invalid", "tomorrow", true, time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), BannedPlayersFile)
+			data := `[{"uuid": "069A79F4-44E9-4726-A5BE-FCA90E38AAF5", "name": "Notch", "created": "2020-01-01 00:00:00 +0000", "source": "Server", "expires": "` + test.expires + `", "reason": "test"}]`
+			if err := os.WriteFile(fileName, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			list, err := NewBanList(fileName, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry := list.GetBan("069a79f4-44e9-4726-a5be-fca90e38aaf5")
+			if (entry != nil) != test.banned {
+				t.Fatalf("GetBan() = %v, want banned %t", entry, test.banned)
+			}
+			if entry != nil && !entry.Expires.Equal(test.want) {
+				t.Errorf("GetBan().Expires = %v, want %v", entry.Expires, test.want)
+			}
+		})
+	}
+}
+
+func TestWhitelistReloadIfChanged(t *testing.T) {
+	added := uuid.New()
+	tests := []struct {
+		name    string
+		modTime time.Duration
+		want    bool
+	}{
+		{"changed", time.Minute, true},
+		{"unchanged", 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), WhitelistFile)
+			list, err := NewWhitelist(fileName, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := list.Add(WhitelistEntry{UniqueID: uuid.New(), Name: "first"}); err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := os.Stat(fileName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data := `[{"uuid": "` + added.String() + `", "name": "second"}]`
+			if err := os.WriteFile(fileName, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			// Pin the mtime so the result doesn't depend on the filesystem timestamp resolution
+			modTime := info.ModTime().Add(test.modTime)
+			if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := list.reloadIfChanged(); err != nil {
+				t.Fatal(err)
+			}
+			if got := list.Contains(added); got != test.want {
+				t.Errorf("Contains() = %t after reloadIfChanged(), want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBanPlayerPardon(t *testing.T) {
+	for _, name := range []string{"Notch", "jeb_", "Dinnerbone"} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(t, Config{})
+			entry, err := BanPlayer(server, name, "Server", "test", time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if server.GetBanList().GetBan(offlineUniqueID(name).String()) == nil {
+				t.Fatalf("BanPlayer() stored %q, not the offline uuid of %s", entry.Target, name)
+			}
+
+			server.DispatchCommand(&apiSource{}, "pardon "+name)
+			if ban := server.GetBanList().GetBan(entry.Target); ban != nil {
+				t.Errorf("pardon %s left the ban on %q", name, ban.Target)
+			}
+		})
+	}
+}
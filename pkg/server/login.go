@@ -0,0 +1,156 @@
+package server
+
+import (
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"time"
+)
+
+const (
+	// DefaultLoginQueryTimeout is how long clients have to answer a login query when no timeout is configured
+	DefaultLoginQueryTimeout = 5 * time.Second
+	// DefaultPreLoginTimeout is how long pre-login handlers can delay the login when no timeout is configured
+	DefaultPreLoginTimeout = 10 * time.Second
+)
+
+var (
+	ErrLoginQueryUnsupported = errors.New("login queries require 1.13 or newer")
+	ErrLoginQueryState       = errors.New("login queries can only be sent while logging in")
+	ErrLoginQueryTimeout     = errors.New("client did not answer the login query in time")
+	ErrPreLoginTimeout       = errors.New("pre-login handlers did not finish in time")
+)
+
+// SendLoginQuery asks the client on the plugin channel and blocks until it answers,
+// clients that don't know the channel answer without being successful
+func (conn *connection) SendLoginQuery(channel string, data []byte) ([]byte, bool, error) {
+	if conn.GetProtocol() < protocol.V1_13 {
+		return nil, false, ErrLoginQueryUnsupported
+	}
+
+	if conn.GetState() != protocol.Login {
+		return nil, false, ErrLoginQueryState
+	}
+
+	response := make(chan *packets.PacketLoginInPluginResponse, 1)
+	conn.mutex.Lock()
+	messageID := conn.nextQueryID
+	conn.nextQueryID++
+	conn.loginQueries[messageID] = response
+	conn.mutex.Unlock()
+
+	defer func() {
+		conn.mutex.Lock()
+		delete(conn.loginQueries, messageID)
+		conn.mutex.Unlock()
+	}()
+
+	if err := conn.WritePacket(&packets.PacketLoginOutPluginRequest{
+		MessageID: messageID,
+		Channel:   channel,
+		Data:      data,
+	}); err != nil {
+		return nil, false, err
+	}
+
+	timer := time.NewTimer(conn.loginQueryTimeout())
+	defer timer.Stop()
+
+	select {
+	case answer := <-response:
+		return answer.Data, answer.Successful, nil
+	case <-timer.C:
+		return nil, false, ErrLoginQueryTimeout
+	case <-conn.ctx.Done():
+		return nil, false, net.ErrClosed
+	}
+}
+
+func (conn *connection) loginQueryTimeout() time.Duration {
+	if timeout := conn.timeouts.LoginQuery; timeout > 0 {
+		return timeout
+	}
+	return DefaultLoginQueryTimeout
+}
+
+// preLoginTimeout bounds the whole pre-login, handlers may send several login queries in it
+func (conn *connection) preLoginTimeout() time.Duration {
+	if timeout := conn.timeouts.PreLogin; timeout > 0 {
+		return timeout
+	}
+	return DefaultPreLoginTimeout
+}
+
+// answerLoginQuery hands the response to whoever is waiting on it, answers nobody asked for are dropped
+func (conn *connection) answerLoginQuery(packet *packets.PacketLoginInPluginResponse) {
+	conn.mutex.RLock()
+	response, ok := conn.loginQueries[packet.MessageID]
+	conn.mutex.RUnlock()
+
+	if ok {
+		select {
+		case response <- packet:
+		default:
+		}
+	}
+}
+
+// login runs off the read loop once the client sent its username,
+// pre-login handlers can then wait on login queries the read loop receives
+func (conn *connection) login() error {
+	event := NewPreLoginEvent(conn)
+	conn.server.FireEvent(OnPlayerPreLoginEvent, event)
+	if err := event.wait(conn.ctx, conn.preLoginTimeout()); err != nil {
+		if !errors.Is(err, ErrPreLoginTimeout) {
+			return err
+		}
+
+		// A handler that never finishes can't hold on to a login slot
+		conn.server.getConnectionLimiter().reject(conn.RemoteAddr(), PreLoginTimeoutRejection)
+		return conn.WritePacket(&packets.PacketLoginOutDisconnect{Reason: timedOutReason})
+	}
+
+	reason := event.GetDisallowReason()
+	if reason == nil {
+		reason = conn.checkAccess()
+	}
+
+	if reason != nil {
+		log.Log.WithValues(
+			"name", conn.GetUsername(),
+			"uuid", conn.GetUniqueID(),
+			"connection", conn.RemoteAddr(),
+		).Info("player was denied access")
+		return conn.WritePacket(&packets.PacketLoginOutDisconnect{Reason: reason})
+	}
+
+	player, online := conn.server.createPlayer(conn)
+	if online {
+		return conn.WritePacket(&packets.PacketLoginOutDisconnect{
+			Reason: coloredText("You are already connected to this server!", &chat.Red),
+		})
+	}
+
+	if err := conn.WritePacket(&packets.PacketLoginOutCompression{
+		Threshold: int32(conn.server.GetConfig().Compression.Threshold),
+	}); err != nil {
+		return err
+	}
+
+	if err := conn.WritePacket(&packets.PacketLoginOutSuccess{
+		UniqueID: player.GetUniqueID(),
+		Username: player.GetUsername(),
+	}); err != nil {
+		return err
+	}
+
+	// Newer clients acknowledge the login and are configured before they can join
+	if conn.GetProtocol() >= protocol.V1_20_2 {
+		return nil
+	}
+
+	return conn.joinGame(player)
+}
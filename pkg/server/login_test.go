@@ -0,0 +1,83 @@
+package server
+
+import (
+	stdbytes "bytes"
+	"context"
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPreLoginEventDelay(t *testing.T) {
+	event := NewPreLoginEvent(nil)
+	if err := event.wait(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("wait() error = %v without delays, want nil", err)
+	}
+
+	done := event.Delay()
+	if err := event.wait(context.Background(), 50*time.Millisecond); !errors.Is(err, ErrPreLoginTimeout) {
+		t.Errorf("wait() error = %v, want %v", err, ErrPreLoginTimeout)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := event.wait(ctx, time.Minute); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("wait() error = %v, want %v", err, net.ErrClosed)
+	}
+
+	done()
+	done()
+	if err := event.wait(context.Background(), time.Minute); err != nil {
+		t.Errorf("wait() error = %v once the delay is done, want nil", err)
+	}
+
+	// Delaying again after every delay was done must not close the channel twice
+	event.Delay()()
+}
+
+func TestPreLoginQuery(t *testing.T) {
+	// The handler takes longer than a single login query in total, but each query is answered in time
+	server := newTestServer(t, Config{
+		Compression: CompressionConf{Threshold: -1},
+		Timeouts:    TimeoutsConf{LoginQuery: 200 * time.Millisecond, PreLogin: 2 * time.Second},
+	})
+
+	answers := make(chan []byte, 1)
+	if err := server.On(OnPlayerPreLoginEvent, func(event PreLoginEvent) {
+		done := event.Delay()
+		go func() {
+			defer done()
+			data, ok, err := event.GetConnection().SendLoginQuery("test:ping", []byte("ping"))
+			if err != nil || !ok {
+				t.Errorf("SendLoginQuery() = %v, %v, want an answer", ok, err)
+			}
+			answers <- data
+
+			time.Sleep(150 * time.Millisecond)
+		}()
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := connect(t, server, protocol.V1_16_4)
+	client.startLogin("Tester")
+
+	request := client.expect(&packets.PacketLoginOutPluginRequest{}).(*packets.PacketLoginOutPluginRequest)
+	time.Sleep(150 * time.Millisecond)
+	client.send(&packets.PacketLoginInPluginResponse{
+		MessageID:  request.MessageID,
+		Successful: true,
+		Data:       []byte("pong"),
+	})
+
+	client.finishLogin()
+	if data := <-answers; !stdbytes.Equal(data, []byte("pong")) {
+		t.Errorf("SendLoginQuery() data = %q, want %q", data, "pong")
+	}
+	if server.GetPlayerByName("Tester") == nil {
+		t.Error("player isn't online after the pre-login finished")
+	}
+}
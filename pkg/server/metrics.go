@@ -0,0 +1,134 @@
+package server
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const metricsNamespace = "mcserver"
+
+var (
+	connectionsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "connections",
+		Help:      "Number of open connections by protocol state.",
+	}, []string{"state"})
+
+	packetsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "packets_total",
+		Help:      "Number of packets read and written by type and direction.",
+	}, []string{"type", "direction"})
+
+	networkBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "network_bytes_total",
+		Help:      "Number of bytes read and written on the wire, after compression.",
+	}, []string{"direction"})
+
+	packetBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "packet_bytes_total",
+		Help:      "Number of packet bytes read and written, before compression.",
+	}, []string{"direction"})
+
+	keepAliveHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "keep_alive_latency_seconds",
+		Help:      "Time players took to answer a keep alive.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	})
+
+	tickHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "tick_duration_seconds",
+		Help:      "Time spent processing a server tick.",
+		Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1},
+	})
+
+	eventHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "event_duration_seconds",
+		Help:      "Time spent running the synchronous handlers of an event.",
+		Buckets:   []float64{.00001, .0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+	}, []string{"event"})
+)
+
+type metricsServer struct {
+	listener net.Listener
+	http     *http.Server
+}
+
+func (metrics *metricsServer) serve() {
+	if err := metrics.http.Serve(metrics.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Log.Error(err, "error occurred while serving metrics")
+	}
+}
+
+func (metrics *metricsServer) Close() error {
+	return metrics.http.Close()
+}
+
+func observePacket(packet protocol.Packet, direction protocol.Direction) {
+	packetsCounter.WithLabelValues(packets.Name(packet), direction.String()).Inc()
+}
+
+func observeBytes(direction protocol.Direction, wire, uncompressed int) {
+	networkBytesCounter.WithLabelValues(direction.String()).Add(float64(wire))
+	packetBytesCounter.WithLabelValues(direction.String()).Add(float64(uncompressed))
+}
+
+func observeDuration(observer prometheus.Observer, start time.Time) {
+	observer.Observe(time.Since(start).Seconds())
+}
+
+func listenMetrics(server Server, config MetricsConf) (*metricsServer, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "players_online",
+			Help:      "Number of players online.",
+		}, func() float64 {
+			return float64(server.GetPlayerCount())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "chunks_loaded",
+			Help:      "Number of chunks loaded in the world.",
+		}, func() float64 {
+			return float64(len(server.GetWorld().GetChunks()))
+		}),
+		connectionsGauge,
+		packetsCounter,
+		networkBytesCounter,
+		packetBytesCounter,
+		keepAliveHistogram,
+		tickHistogram,
+		eventHistogram,
+		rejectionsCounter,
+	)
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return &metricsServer{
+		listener: listener,
+		http:     &http.Server{Handler: mux},
+	}, nil
+}
@@ -1,22 +1,42 @@
 package server
 
 import (
+	"errors"
 	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/permission"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/protocol/sounds"
 	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+const (
+	DefaultFlySpeed  = 0.05
+	DefaultWalkSpeed = 0.1
+)
+
+var (
+	ErrFlightNotAllowed         = errors.New("player is not allowed to fly")
+	ErrConfigurationUnsupported = errors.New("player protocol has no configuration state")
+
+	spawnPosition = Position{X: 0.5, Y: 65, Z: 0.5}
+)
+
 type (
 	Player interface {
 		GetServer() Server
 		GetUniqueID() uuid.UUID
 		GetUsername() string
+		GetName() string
 		GetProtocol() protocol.Protocol
 		GetState() protocol.State
+		GetAddress() net.Addr
+		getConnection() Connection
 		setLatency(latency time.Duration)
 		GetLatency() time.Duration
 		setKeepAlivePending(keepAlivePending bool)
@@ -25,7 +45,37 @@ type (
 		GetLastKeepAliveTime() time.Time
 		setLastKeepAliveID(lastKeepAliveID int32)
 		GetLastKeepAliveID() int32
+		setPosition(position Position)
+		GetPosition() Position
+		SetGameMode(gameMode protocol.GameMode) error
+		GetGameMode() protocol.GameMode
+		setFlying(flying bool)
+		SetFlying(flying bool) error
+		IsFlying() bool
+		SetAllowFlight(allowFlight bool) error
+		GetAllowFlight() bool
+		SetFlySpeed(speed float32) error
+		GetFlySpeed() float32
+		sendAbilities() error
 		SendPacket(packet protocol.Packet) error
+		SendMessage(message []chat.Component) error
+		SendPluginMessage(channel string, data []byte) error
+		setClientBrand(brand string)
+		GetClientBrand() string
+		registerChannels(channels []string)
+		unregisterChannels(channels []string)
+		// GetChannels returns the plugin channels the client registered
+		GetChannels() []string
+		permission.Permissible
+		UpdateCommands() error
+		SendTitle(title, subtitle []chat.Component, fadeIn, stay, fadeOut time.Duration) error
+		SendActionBar(message []chat.Component) error
+		ResetTitle() error
+		Reconfigure() error
+		PlaySound(sound string, category sounds.Category, position Position, volume, pitch float32) error
+		SpawnParticle(effect ParticleEffect) error
+		SetScoreboard(scoreboard Scoreboard) error
+		GetScoreboard() Scoreboard
 		Kick(reason []chat.Component) error
 	}
 
@@ -38,6 +88,13 @@ type (
 		keepAlivePending  bool
 		lastKeepAliveTime time.Time
 		lastKeepAliveID   int32
+		position          Position
+		gameMode          protocol.GameMode
+		abilities         packets.AbilityFlags
+		flySpeed          float32
+		scoreboard        Scoreboard
+		clientBrand       string
+		channels          map[string]struct{}
 	}
 )
 
@@ -53,6 +110,11 @@ func (player *player) GetUsername() string {
 	return player.conn.GetUsername()
 }
 
+// GetName is the same as GetUsername, it makes players usable as a command source
+func (player *player) GetName() string {
+	return player.GetUsername()
+}
+
 func (player *player) GetProtocol() protocol.Protocol {
 	return player.conn.GetProtocol()
 }
@@ -61,6 +123,14 @@ func (player *player) GetState() protocol.State {
 	return player.conn.GetState()
 }
 
+func (player *player) GetAddress() net.Addr {
+	return player.conn.RemoteAddr()
+}
+
+func (player *player) getConnection() Connection {
+	return player.conn
+}
+
 func (player *player) setLatency(latency time.Duration) {
 	player.latency.Store(latency)
 }
@@ -105,25 +175,392 @@ func (player *player) GetLastKeepAliveID() int32 {
 	return player.lastKeepAliveID
 }
 
+func (player *player) setPosition(position Position) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	player.position = position
+}
+
+func (player *player) GetPosition() Position {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.position
+}
+
+func (player *player) SetGameMode(gameMode protocol.GameMode) error {
+	player.mutex.Lock()
+	player.gameMode = gameMode
+	player.abilities = gameModeAbilities(gameMode)
+	player.mutex.Unlock()
+
+	if err := player.SendPacket(&packets.PacketPlayOutChangeGameState{
+		Reason: packets.ChangeGameModeReason,
+		Value:  float32(gameMode),
+	}); err != nil {
+		return err
+	}
+	return player.sendAbilities()
+}
+
+func (player *player) GetGameMode() protocol.GameMode {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.gameMode
+}
+
+func (player *player) setFlying(flying bool) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	if flying && player.abilities&packets.AllowFlyingAbilityFlag != 0 {
+		player.abilities |= packets.FlyingAbilityFlag
+	} else {
+		player.abilities &^= packets.FlyingAbilityFlag
+	}
+}
+
+func (player *player) SetFlying(flying bool) error {
+	if flying && !player.GetAllowFlight() {
+		return ErrFlightNotAllowed
+	}
+
+	player.setFlying(flying)
+	return player.sendAbilities()
+}
+
+func (player *player) IsFlying() bool {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.abilities&packets.FlyingAbilityFlag != 0
+}
+
+func (player *player) SetAllowFlight(allowFlight bool) error {
+	player.mutex.Lock()
+	if allowFlight {
+		player.abilities |= packets.AllowFlyingAbilityFlag
+	} else {
+		player.abilities &^= packets.AllowFlyingAbilityFlag | packets.FlyingAbilityFlag
+	}
+	player.mutex.Unlock()
+
+	return player.sendAbilities()
+}
+
+func (player *player) GetAllowFlight() bool {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.abilities&packets.AllowFlyingAbilityFlag != 0
+}
+
+func (player *player) SetFlySpeed(speed float32) error {
+	player.mutex.Lock()
+	player.flySpeed = speed
+	player.mutex.Unlock()
+
+	return player.sendAbilities()
+}
+
+func (player *player) GetFlySpeed() float32 {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.flySpeed
+}
+
+func (player *player) sendAbilities() error {
+	player.mutex.RLock()
+	packet := &packets.PacketPlayOutAbilities{
+		Flags:       player.abilities,
+		FlyingSpeed: player.flySpeed,
+		FieldOfView: DefaultWalkSpeed,
+	}
+	player.mutex.RUnlock()
+
+	return player.SendPacket(packet)
+}
+
 func (player *player) SendPacket(packet protocol.Packet) error {
 	return player.conn.WritePacket(packet)
 }
 
+func (player *player) SendMessage(message []chat.Component) error {
+	return player.SendPacket(&packets.PacketPlayOutChatMessage{
+		Message:  player.resolveScores(message),
+		Position: 1,
+	})
+}
+
+// SendPluginMessage sends the data on a namespaced channel, it's translated for clients older than 1.13
+func (player *player) SendPluginMessage(channel string, data []byte) error {
+	if player.GetState() == protocol.Configuration {
+		return player.SendPacket(&packets.PacketConfigurationOutPluginMessage{
+			Channel: channel,
+			Data:    data,
+		})
+	}
+
+	return player.SendPacket(&packets.PacketPlayOutPluginMessage{
+		Channel: channel,
+		Data:    data,
+	})
+}
+
+func (player *player) setClientBrand(brand string) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	player.clientBrand = brand
+}
+
+func (player *player) GetClientBrand() string {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.clientBrand
+}
+
+// registerChannels ignores invalid names and anything past MaxPlayerChannels,
+// the client decides what's in there so it can't be trusted to keep it small
+func (player *player) registerChannels(channels []string) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	for _, channel := range channels {
+		if len(player.channels) >= MaxPlayerChannels {
+			return
+		}
+
+		if isValidChannel(channel) {
+			player.channels[channel] = struct{}{}
+		}
+	}
+}
+
+func (player *player) unregisterChannels(channels []string) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	for _, channel := range channels {
+		delete(player.channels, channel)
+	}
+}
+
+func (player *player) GetChannels() []string {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+
+	var channels []string
+	for channel := range player.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+func (player *player) HasPermission(permission string) bool {
+	return player.GetServer().GetPermissions().HasPermission(player.GetUniqueID(), permission)
+}
+
+func (player *player) IsOperator() bool {
+	return player.GetServer().GetPermissions().IsOperator(player.GetUniqueID())
+}
+
+// UpdateCommands sends the commands the player can use, needed after the tree or its permissions change
+func (player *player) UpdateCommands() error {
+	// Older clients don't know about the command tree and use tab complete requests instead
+	if player.GetProtocol() < protocol.V1_13 {
+		return nil
+	}
+
+	packet, err := newDeclareCommands(player.GetServer().GetCommandDispatcher().GetRoot(), player)
+	if err != nil {
+		return err
+	}
+	return player.SendPacket(packet)
+}
+
+func (player *player) SendTitle(title, subtitle []chat.Component, fadeIn, stay, fadeOut time.Duration) error {
+	if err := player.sendTitle(&packets.PacketPlayOutTitle{
+		Action:  packets.SetTimesAction,
+		FadeIn:  toTicks(fadeIn),
+		Stay:    toTicks(stay),
+		FadeOut: toTicks(fadeOut),
+	}); err != nil {
+		return err
+	}
+
+	if subtitle != nil {
+		if err := player.sendTitle(&packets.PacketPlayOutTitle{
+			Action: packets.SetSubtitleAction,
+			Text:   player.resolveScores(subtitle),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The title action is what makes the client display both texts,
+	// so an empty one is sent when only a subtitle is wanted
+	if title == nil {
+		title = []chat.Component{&chat.TextComponent{}}
+	}
+
+	return player.sendTitle(&packets.PacketPlayOutTitle{
+		Action: packets.SetTitleAction,
+		Text:   player.resolveScores(title),
+	})
+}
+
+func (player *player) SendActionBar(message []chat.Component) error {
+	message = player.resolveScores(message)
+	if player.GetProtocol() >= protocol.V1_11 {
+		return player.sendTitle(&packets.PacketPlayOutTitle{
+			Action: packets.SetActionBarAction,
+			Text:   message,
+		})
+	}
+
+	// Older clients only render legacy formatted text above the hotbar
+	return player.SendPacket(&packets.PacketPlayOutChatMessage{
+		Message: []chat.Component{
+			&chat.TextComponent{Text: chat.ToLegacyText(message)},
+		},
+		Position: packets.ActionBarChatPosition,
+	})
+}
+
+func (player *player) ResetTitle() error {
+	return player.sendTitle(&packets.PacketPlayOutTitle{
+		Action: packets.ResetTitleAction,
+	})
+}
+
+// resolveScores fills in the score components of the message with the values this player sees
+func (player *player) resolveScores(message []chat.Component) []chat.Component {
+	if scoreboard := player.GetScoreboard(); scoreboard != nil {
+		return scoreboard.ResolveScores(message, player)
+	}
+	return message
+}
+
+// sendTitle sends a title action, which got a packet of its own from 1.17
+func (player *player) sendTitle(packet *packets.PacketPlayOutTitle) error {
+	if player.GetProtocol() < protocol.V1_17 {
+		return player.SendPacket(packet)
+	}
+
+	switch packet.Action {
+	case packets.SetTitleAction:
+		return player.SendPacket(&packets.PacketPlayOutTitleText{Text: packet.Text})
+	case packets.SetSubtitleAction:
+		return player.SendPacket(&packets.PacketPlayOutSubtitleText{Text: packet.Text})
+	case packets.SetActionBarAction:
+		return player.SendPacket(&packets.PacketPlayOutActionBar{Text: packet.Text})
+	case packets.SetTimesAction:
+		return player.SendPacket(&packets.PacketPlayOutTitleTimes{
+			FadeIn:  packet.FadeIn,
+			Stay:    packet.Stay,
+			FadeOut: packet.FadeOut,
+		})
+	default:
+		return player.SendPacket(&packets.PacketPlayOutClearTitles{
+			Reset: packet.Action == packets.ResetTitleAction,
+		})
+	}
+}
+
+// Reconfigure sends the player back into configuration, they get the registries
+// again and rejoin where they were once it finishes
+func (player *player) Reconfigure() error {
+	if player.GetProtocol() < protocol.V1_20_2 {
+		return ErrConfigurationUnsupported
+	}
+	return player.SendPacket(&packets.PacketPlayOutStartConfiguration{})
+}
+
+func (player *player) PlaySound(sound string, category sounds.Category, position Position, volume, pitch float32) error {
+	return player.SendPacket(&packets.PacketPlayOutNamedSoundEffect{
+		Sound:    sounds.GetSoundName(sound, player.GetProtocol()),
+		Category: category,
+		X:        position.X,
+		Y:        position.Y,
+		Z:        position.Z,
+		Volume:   volume,
+		Pitch:    pitch,
+	})
+}
+
+func (player *player) SpawnParticle(effect ParticleEffect) error {
+	packet, err := effect.toPacket(player.GetProtocol())
+	if err != nil || packet == nil {
+		return err
+	}
+	return player.SendPacket(packet)
+}
+
+func (player *player) SetScoreboard(scoreboard Scoreboard) error {
+	player.mutex.Lock()
+	previous := player.scoreboard
+	player.scoreboard = scoreboard
+	player.mutex.Unlock()
+
+	if previous == scoreboard {
+		return nil
+	}
+
+	if previous != nil {
+		if err := previous.removePlayer(player); err != nil {
+			return err
+		}
+	}
+
+	if scoreboard != nil {
+		return scoreboard.addPlayer(player)
+	}
+	return nil
+}
+
+func (player *player) GetScoreboard() Scoreboard {
+	player.mutex.RLock()
+	defer player.mutex.RUnlock()
+	return player.scoreboard
+}
+
 func (player *player) Kick(reason []chat.Component) error {
-	if player.GetState() == protocol.Handshaking || player.GetState() == protocol.Login {
+	switch player.GetState() {
+	case protocol.Handshaking, protocol.Login:
 		return player.SendPacket(&packets.PacketLoginOutDisconnect{
 			Reason: reason,
 		})
-	} else {
+	case protocol.Configuration:
+		return player.SendPacket(&packets.PacketConfigurationOutDisconnect{
+			Reason: reason,
+		})
+	default:
 		return player.SendPacket(&packets.PacketPlayOutDisconnect{
 			Reason: reason,
 		})
 	}
 }
 
+// gameModeAbilities returns the abilities the client assigns itself for the given game mode
+func gameModeAbilities(gameMode protocol.GameMode) packets.AbilityFlags {
+	switch gameMode {
+	case protocol.Creative:
+		return packets.InvulnerableAbilityFlag | packets.AllowFlyingAbilityFlag | packets.CreativeModeAbilityFlag
+	case protocol.Spectator:
+		return packets.InvulnerableAbilityFlag | packets.AllowFlyingAbilityFlag | packets.FlyingAbilityFlag
+	default:
+		return 0
+	}
+}
+
+func toTicks(duration time.Duration) int32 {
+	return int32(duration / (50 * time.Millisecond))
+}
+
 func newPlayer(conn Connection) Player {
+	gameMode := conn.GetServer().GetWorld().GetDefaultGameMode()
 	player := &player{
-		conn: conn,
+		conn:      conn,
+		gameMode:  gameMode,
+		abilities: gameModeAbilities(gameMode),
+		position:  spawnPosition,
+		flySpeed:  DefaultFlySpeed,
+		channels:  make(map[string]struct{}),
 	}
 	player.setLatency(-1)
 	return player
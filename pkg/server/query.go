@@ -0,0 +1,201 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	queryTypeStat      byte = 0x00
+	queryTypeHandshake byte = 0x09
+
+	// Challenge tokens are valid for at least this long, after which clients need a new handshake
+	queryChallengeLifetime = 30 * time.Second
+	queryMaxPacketSize     = 1460
+)
+
+var (
+	queryMagic = []byte{0xFE, 0xFD}
+
+	queryFullStatPadding   = []byte("splitnum\x00\x80\x00")
+	queryPlayerListPadding = []byte("\x01player_\x00\x00")
+)
+
+type (
+	queryServer struct {
+		server Server
+		conn   net.PacketConn
+		host   string
+		port   int
+		secret []byte
+	}
+)
+
+func (query *queryServer) serve() {
+	buffer := make([]byte, queryMaxPacketSize)
+	for {
+		n, addr, err := query.conn.ReadFrom(buffer)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Log.Error(err, "error occurred while reading a query packet")
+				continue
+			}
+			return
+		}
+
+		response := query.handle(addr, buffer[:n])
+		if response == nil {
+			continue
+		}
+
+		if _, err := query.conn.WriteTo(response, addr); err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Log.WithValues(
+					"connection", addr,
+				).Error(err, "got error during query packet write")
+			}
+		}
+	}
+}
+
+func (query *queryServer) handle(addr net.Addr, packet []byte) []byte {
+	// magic, type and session id are always present
+	if len(packet) < 7 || !bytes.Equal(packet[0:2], queryMagic) {
+		return nil
+	}
+
+	kind, sessionID := packet[2], packet[3:7]
+	switch kind {
+	case queryTypeHandshake:
+		response := newQueryResponse(kind, sessionID)
+		writeQueryString(response, strconv.Itoa(int(query.newChallenge(addr))))
+		return response.Bytes()
+	case queryTypeStat:
+		if len(packet) < 11 || !query.verifyChallenge(addr, int32(binary.BigEndian.Uint32(packet[7:11]))) {
+			return nil
+		}
+
+		// Full stat requests are padded with 4 extra bytes
+		if len(packet) >= 15 {
+			return query.fullStat(sessionID)
+		}
+		return query.basicStat(sessionID)
+	}
+	return nil
+}
+
+func (query *queryServer) basicStat(sessionID []byte) []byte {
+	response := newQueryResponse(queryTypeStat, sessionID)
+	writeQueryString(response, chat.StripColors(chat.ToLegacyText(statusDescription)))
+	writeQueryString(response, "SMP")
+	writeQueryString(response, query.server.GetWorld().GetName())
+	writeQueryString(response, strconv.Itoa(query.server.GetPlayerCount()))
+	writeQueryString(response, strconv.Itoa(query.server.GetPlayerCount()))
+	_ = binary.Write(response, binary.LittleEndian, uint16(query.port))
+	writeQueryString(response, query.host)
+	return response.Bytes()
+}
+
+func (query *queryServer) fullStat(sessionID []byte) []byte {
+	players := query.server.GetPlayers()
+
+	response := newQueryResponse(queryTypeStat, sessionID)
+	response.Write(queryFullStatPadding)
+	for _, pair := range [][2]string{
+		{"hostname", chat.StripColors(chat.ToLegacyText(statusDescription))},
+		{"gametype", "SMP"},
+		{"game_id", "MINECRAFT"},
+		{"version", chat.StripColors(statusVersion)},
+		{"plugins", ""},
+		{"map", query.server.GetWorld().GetName()},
+		{"numplayers", strconv.Itoa(len(players))},
+		{"maxplayers", strconv.Itoa(query.server.GetPlayerCount())},
+		{"hostport", strconv.Itoa(query.port)},
+		{"hostip", query.host},
+	} {
+		writeQueryString(response, pair[0])
+		writeQueryString(response, pair[1])
+	}
+	response.WriteByte(0x00)
+
+	response.Write(queryPlayerListPadding)
+	for _, player := range players {
+		writeQueryString(response, player.GetUsername())
+	}
+	response.WriteByte(0x00)
+	return response.Bytes()
+}
+
+// newChallenge derives the token from the address instead of storing it,
+// so handshakes from spoofed addresses don't cost us any memory
+func (query *queryServer) newChallenge(addr net.Addr) int32 {
+	return query.challengeToken(addr, queryChallengeWindow(time.Now()))
+}
+
+// verifyChallenge also accepts tokens of the previous window, tokens handed out
+// right before a window ends are then still valid for a whole lifetime
+func (query *queryServer) verifyChallenge(addr net.Addr, token int32) bool {
+	window := queryChallengeWindow(time.Now())
+	return token == query.challengeToken(addr, window) || token == query.challengeToken(addr, window-1)
+}
+
+func (query *queryServer) challengeToken(addr net.Addr, window int64) int32 {
+	mac := hmac.New(sha256.New, query.secret)
+	_ = binary.Write(mac, binary.BigEndian, window)
+	mac.Write([]byte(addr.String()))
+	return int32(binary.BigEndian.Uint32(mac.Sum(nil)))
+}
+
+func queryChallengeWindow(now time.Time) int64 {
+	return now.UnixNano() / int64(queryChallengeLifetime)
+}
+
+func (query *queryServer) Close() error {
+	return query.conn.Close()
+}
+
+func newQueryResponse(kind byte, sessionID []byte) *bytes.Buffer {
+	response := bytes.NewBuffer(make([]byte, 0, 64))
+	response.WriteByte(kind)
+	response.Write(sessionID)
+	return response
+}
+
+func writeQueryString(buffer *bytes.Buffer, value string) {
+	buffer.WriteString(value)
+	buffer.WriteByte(0x00)
+}
+
+func listenQuery(server Server, config QueryConf) (*queryServer, error) {
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenPacket("udp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	host := server.GetConfig().Host
+	if host == "" {
+		host = "0.0.0.0"
+	}
+
+	return &queryServer{
+		server: server,
+		conn:   conn,
+		host:   host,
+		port:   server.GetConfig().Port,
+		secret: secret,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"net"
+	"testing"
+)
+
+func TestQueryChallenge(t *testing.T) {
+	query := &queryServer{secret: []byte("secret")}
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 25565}
+	other := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 2), Port: 25565}
+
+	token := query.newChallenge(addr)
+	if !query.verifyChallenge(addr, token) {
+		t.Errorf("verifyChallenge() = false for the token of the same address")
+	}
+
+	if query.verifyChallenge(other, token) {
+		t.Errorf("verifyChallenge() = true for the token of another address")
+	}
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	rconTypeResponse     int32 = 0
+	rconTypeCommand      int32 = 2
+	rconTypeAuthResponse int32 = 2
+	rconTypeAuth         int32 = 3
+
+	// Vanilla splits responses in bodies of this size, clients reassemble them
+	rconMaxResponseBody = 4096
+	rconMaxRequestBody  = 1446
+
+	// DefaultRConTimeout is how long rcon connections can stay idle when no read timeout is configured
+	DefaultRConTimeout = 30 * time.Second
+)
+
+var ErrRConNoPassword = errors.New("rcon password is not set")
+
+type (
+	rconServer struct {
+		server   Server
+		listener net.Listener
+		password string
+		timeout  time.Duration
+		wait     sync.WaitGroup
+
+		mutex sync.Mutex
+		conns map[net.Conn]struct{}
+	}
+
+	// rconSource collects every message sent to it as the command response
+	rconSource struct {
+		mutex  sync.Mutex
+		output strings.Builder
+	}
+
+	rconPacket struct {
+		id   int32
+		kind int32
+		body string
+	}
+)
+
+// serve returns once the listener is closed and every connection was handled
+func (rcon *rconServer) serve() {
+	defer rcon.wait.Wait()
+
+	for {
+		conn, err := rcon.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Log.Error(err, "error occurred while accepting a new rcon connection")
+				continue
+			}
+			return
+		}
+
+		rcon.mutex.Lock()
+		rcon.conns[conn] = struct{}{}
+		rcon.mutex.Unlock()
+
+		rcon.wait.Add(1)
+		go func() {
+			defer rcon.wait.Done()
+			rcon.handle(conn)
+		}()
+	}
+}
+
+func (rcon *rconServer) handle(conn net.Conn) {
+	defer func() {
+		rcon.mutex.Lock()
+		delete(rcon.conns, conn)
+		rcon.mutex.Unlock()
+		_ = conn.Close()
+	}()
+
+	authenticated := false
+	for {
+		// Reset for every packet so connections that never authenticate don't stay open
+		if err := conn.SetReadDeadline(time.Now().Add(rcon.timeout)); err != nil {
+			return
+		}
+
+		packet, err := readRConPacket(conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) && !errors.Is(err, os.ErrDeadlineExceeded) {
+				log.Log.WithValues(
+					"connection", conn.RemoteAddr(),
+				).Error(err, "got error during rcon packet read")
+			}
+			return
+		}
+
+		switch {
+		case packet.kind == rconTypeAuth:
+			if subtle.ConstantTimeCompare([]byte(packet.body), []byte(rcon.password)) == 1 {
+				authenticated = true
+				log.Log.WithValues(
+					"connection", conn.RemoteAddr(),
+				).Info("rcon connection authenticated")
+				err = writeRConPacket(conn, rconPacket{id: packet.id, kind: rconTypeAuthResponse})
+			} else {
+				authenticated = false
+				log.Log.WithValues(
+					"connection", conn.RemoteAddr(),
+				).Info("rcon connection failed to authenticate")
+				err = writeRConPacket(conn, rconPacket{id: -1, kind: rconTypeAuthResponse})
+			}
+		case !authenticated:
+			err = writeRConPacket(conn, rconPacket{id: -1, kind: rconTypeAuthResponse})
+		case packet.kind == rconTypeCommand:
+			log.Log.WithValues(
+				"connection", conn.RemoteAddr(),
+				"command", packet.body,
+			).Info("rcon issued server command")
+
+			source := &rconSource{}
+			rcon.server.DispatchCommand(source, strings.TrimPrefix(packet.body, "/"))
+			err = writeRConResponse(conn, packet.id, source.getOutput())
+		case packet.kind == rconTypeResponse:
+			// Clients send an empty response after a command to find where a split response ends,
+			// answering it right away marks the end since requests are processed in order
+			err = writeRConPacket(conn, rconPacket{id: packet.id, kind: rconTypeResponse})
+		}
+
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Log.WithValues(
+					"connection", conn.RemoteAddr(),
+				).Error(err, "got error during rcon packet write")
+			}
+			return
+		}
+	}
+}
+
+func (rcon *rconServer) Close() error {
+	err := rcon.listener.Close()
+
+	rcon.mutex.Lock()
+	defer rcon.mutex.Unlock()
+	for conn := range rcon.conns {
+		_ = conn.Close()
+	}
+	return err
+}
+
+func (source *rconSource) GetName() string {
+	return "Rcon"
+}
+
+func (source *rconSource) SendMessage(message []chat.Component) error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.output.Len() > 0 {
+		source.output.WriteString("\n")
+	}
+	source.output.WriteString(chat.ToLegacyText(message))
+	return nil
+}
+
+func (source *rconSource) HasPermission(_ string) bool {
+	return true
+}
+
+func (source *rconSource) IsOperator() bool {
+	return true
+}
+
+func (source *rconSource) getOutput() string {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.output.String()
+}
+
+func readRConPacket(reader io.Reader) (rconPacket, error) {
+	var length int32
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return rconPacket{}, err
+	}
+
+	// id, type and the two null terminators are always present
+	if length < 10 || length > 10+rconMaxRequestBody {
+		return rconPacket{}, errors.New("received invalid rcon packet length")
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return rconPacket{}, err
+	}
+
+	return rconPacket{
+		id:   int32(binary.LittleEndian.Uint32(payload[0:4])),
+		kind: int32(binary.LittleEndian.Uint32(payload[4:8])),
+		body: strings.TrimRight(string(payload[8:]), "\x00"),
+	}, nil
+}
+
+func writeRConPacket(writer io.Writer, packet rconPacket) error {
+	payload := make([]byte, 12, 14+len(packet.body))
+	binary.LittleEndian.PutUint32(payload[0:4], uint32(10+len(packet.body)))
+	binary.LittleEndian.PutUint32(payload[4:8], uint32(packet.id))
+	binary.LittleEndian.PutUint32(payload[8:12], uint32(packet.kind))
+	payload = append(payload, packet.body...)
+	payload = append(payload, 0, 0)
+
+	_, err := writer.Write(payload)
+	return err
+}
+
+func writeRConResponse(writer io.Writer, id int32, response string) error {
+	for {
+		body := response
+		if len(body) > rconMaxResponseBody {
+			// Split on a rune boundary so clients never get half a character
+			cut := rconMaxResponseBody
+			for cut > rconMaxResponseBody-utf8.UTFMax && !utf8.RuneStart(body[cut]) {
+				cut--
+			}
+			body = body[:cut]
+		}
+		response = response[len(body):]
+
+		if err := writeRConPacket(writer, rconPacket{id: id, kind: rconTypeResponse, body: body}); err != nil {
+			return err
+		}
+
+		if response == "" {
+			return nil
+		}
+	}
+}
+
+func listenRCon(server Server, config RConConf) (*rconServer, error) {
+	if config.Password == "" {
+		return nil, ErrRConNoPassword
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := server.GetConfig().Timeouts.Read
+	if timeout <= 0 {
+		timeout = DefaultRConTimeout
+	}
+
+	return &rconServer{
+		server:   server,
+		listener: listener,
+		password: config.Password,
+		timeout:  timeout,
+		conns:    make(map[net.Conn]struct{}),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWriteRConResponse(t *testing.T) {
+	var buffer bytes.Buffer
+	response := "a" + strings.Repeat("é", rconMaxResponseBody)
+	if err := writeRConResponse(&buffer, 1, response); err != nil {
+		t.Fatal(err)
+	}
+
+	var got strings.Builder
+	for buffer.Len() > 0 {
+		length := int(binary.LittleEndian.Uint32(buffer.Next(4)))
+		body := buffer.Next(length)[8 : length-2]
+		if len(body) > rconMaxResponseBody || !utf8.Valid(body) {
+			t.Fatalf("writeRConResponse() wrote a body of %d bytes that isn't valid utf8", len(body))
+		}
+		got.Write(body)
+	}
+
+	if got.String() != response {
+		t.Errorf("writeRConResponse() split bodies don't add up to the response")
+	}
+}
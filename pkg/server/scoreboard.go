@@ -0,0 +1,742 @@
+package server
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var (
+	ErrObjectiveRegistered = errors.New("objective already registered")
+	ErrTeamRegistered      = errors.New("team already registered")
+	ErrNameTooLong         = errors.New("name can't be longer than 16 characters")
+)
+
+type (
+	Scoreboard interface {
+		RegisterObjective(name string, displayName []chat.Component, renderType packets.ObjectiveRenderType) (Objective, error)
+		UnregisterObjective(name string)
+		GetObjective(name string) Objective
+		GetObjectives() []Objective
+		SetDisplaySlot(slot packets.DisplaySlot, objective Objective)
+		GetDisplaySlot(slot packets.DisplaySlot) Objective
+		RegisterTeam(name string) (Team, error)
+		UnregisterTeam(name string)
+		GetTeam(name string) Team
+		GetTeams() []Team
+		GetEntryTeam(entry string) Team
+		ResetScores(entry string)
+		ResolveScores(components []chat.Component, viewer Player) []chat.Component
+		GetPlayers() []Player
+
+		addPlayer(player Player) error
+		removePlayer(player Player) error
+	}
+
+	Objective interface {
+		GetScoreboard() Scoreboard
+		GetName() string
+		SetDisplayName(displayName []chat.Component)
+		GetDisplayName() []chat.Component
+		SetRenderType(renderType packets.ObjectiveRenderType)
+		GetRenderType() packets.ObjectiveRenderType
+		SetScore(entry string, value int32)
+		GetScore(entry string) (int32, bool)
+		GetScores() map[string]int32
+		ResetScore(entry string)
+	}
+
+	Team interface {
+		GetScoreboard() Scoreboard
+		GetName() string
+		SetDisplayName(displayName []chat.Component)
+		GetDisplayName() []chat.Component
+		SetPrefix(prefix []chat.Component)
+		GetPrefix() []chat.Component
+		SetSuffix(suffix []chat.Component)
+		GetSuffix() []chat.Component
+		SetColor(color *chat.Color)
+		GetColor() *chat.Color
+		SetFriendlyFire(friendlyFire bool)
+		HasFriendlyFire() bool
+		SetSeeInvisible(seeInvisible bool)
+		CanSeeInvisible() bool
+		SetNameTagVisibility(visibility packets.NameTagVisibility)
+		GetNameTagVisibility() packets.NameTagVisibility
+		SetCollisionRule(rule packets.CollisionRule)
+		GetCollisionRule() packets.CollisionRule
+		AddEntry(entry string)
+		RemoveEntry(entry string)
+		HasEntry(entry string) bool
+		GetEntries() []string
+	}
+
+	scoreboard struct {
+		mutex      sync.RWMutex
+		objectives map[string]*objective
+		slots      map[packets.DisplaySlot]*objective
+		teams      map[string]*team
+		entries    map[string]*team
+		players    map[uuid.UUID]Player
+	}
+
+	objective struct {
+		scoreboard *scoreboard
+
+		name        string
+		displayName []chat.Component
+		renderType  packets.ObjectiveRenderType
+		scores      map[string]int32
+	}
+
+	team struct {
+		scoreboard *scoreboard
+
+		name              string
+		displayName       []chat.Component
+		prefix            []chat.Component
+		suffix            []chat.Component
+		color             *chat.Color
+		friendlyFlags     packets.TeamFriendlyFlags
+		nameTagVisibility packets.NameTagVisibility
+		collisionRule     packets.CollisionRule
+		entries           map[string]struct{}
+	}
+)
+
+func (sb *scoreboard) RegisterObjective(name string, displayName []chat.Component, renderType packets.ObjectiveRenderType) (Objective, error) {
+	if len(name) > 16 {
+		return nil, ErrNameTooLong
+	}
+
+	sb.mutex.Lock()
+	if _, ok := sb.objectives[name]; ok {
+		sb.mutex.Unlock()
+		return nil, ErrObjectiveRegistered
+	}
+
+	obj := &objective{
+		scoreboard:  sb,
+		name:        name,
+		displayName: displayName,
+		renderType:  renderType,
+		scores:      make(map[string]int32),
+	}
+	sb.objectives[name] = obj
+	packet := obj.createPacket(packets.CreateObjectiveMode)
+	sb.mutex.Unlock()
+
+	sb.broadcast(packet)
+	return obj, nil
+}
+
+func (sb *scoreboard) UnregisterObjective(name string) {
+	sb.mutex.Lock()
+	obj, ok := sb.objectives[name]
+	if !ok {
+		sb.mutex.Unlock()
+		return
+	}
+
+	delete(sb.objectives, name)
+	for slot, slotObj := range sb.slots {
+		if slotObj == obj {
+			delete(sb.slots, slot)
+		}
+	}
+	sb.mutex.Unlock()
+
+	sb.broadcast(&packets.PacketPlayOutScoreboardObjective{
+		Name: name,
+		Mode: packets.RemoveObjectiveMode,
+	})
+}
+
+func (sb *scoreboard) GetObjective(name string) Objective {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+	if obj, ok := sb.objectives[name]; ok {
+		return obj
+	}
+	return nil
+}
+
+func (sb *scoreboard) GetObjectives() []Objective {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+
+	var objectives []Objective
+	for _, obj := range sb.objectives {
+		objectives = append(objectives, obj)
+	}
+	return objectives
+}
+
+func (sb *scoreboard) SetDisplaySlot(slot packets.DisplaySlot, obj Objective) {
+	var name string
+
+	sb.mutex.Lock()
+	if obj, ok := obj.(*objective); ok && obj != nil && obj.scoreboard == sb {
+		sb.slots[slot] = obj
+		name = obj.name
+	} else {
+		delete(sb.slots, slot)
+	}
+	sb.mutex.Unlock()
+
+	sb.broadcast(&packets.PacketPlayOutDisplayScoreboard{
+		Position:  slot,
+		ScoreName: name,
+	})
+}
+
+func (sb *scoreboard) GetDisplaySlot(slot packets.DisplaySlot) Objective {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+	if obj, ok := sb.slots[slot]; ok {
+		return obj
+	}
+	return nil
+}
+
+func (sb *scoreboard) RegisterTeam(name string) (Team, error) {
+	if len(name) > 16 {
+		return nil, ErrNameTooLong
+	}
+
+	sb.mutex.Lock()
+	if _, ok := sb.teams[name]; ok {
+		sb.mutex.Unlock()
+		return nil, ErrTeamRegistered
+	}
+
+	t := &team{
+		scoreboard:        sb,
+		name:              name,
+		displayName:       []chat.Component{&chat.TextComponent{Text: name}},
+		nameTagVisibility: packets.AlwaysNameTagVisibility,
+		collisionRule:     packets.AlwaysCollisionRule,
+		entries:           make(map[string]struct{}),
+	}
+	sb.teams[name] = t
+	packet := t.createPacket(packets.CreateTeamMode)
+	sb.mutex.Unlock()
+
+	sb.broadcast(packet)
+	return t, nil
+}
+
+func (sb *scoreboard) UnregisterTeam(name string) {
+	sb.mutex.Lock()
+	t, ok := sb.teams[name]
+	if !ok {
+		sb.mutex.Unlock()
+		return
+	}
+
+	delete(sb.teams, name)
+	for entry := range t.entries {
+		delete(sb.entries, entry)
+	}
+	sb.mutex.Unlock()
+
+	sb.broadcast(&packets.PacketPlayOutTeams{
+		Name: name,
+		Mode: packets.RemoveTeamMode,
+	})
+}
+
+func (sb *scoreboard) GetTeam(name string) Team {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+	if t, ok := sb.teams[name]; ok {
+		return t
+	}
+	return nil
+}
+
+func (sb *scoreboard) GetTeams() []Team {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+
+	var teams []Team
+	for _, t := range sb.teams {
+		teams = append(teams, t)
+	}
+	return teams
+}
+
+func (sb *scoreboard) GetEntryTeam(entry string) Team {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+	if t, ok := sb.entries[entry]; ok {
+		return t
+	}
+	return nil
+}
+
+func (sb *scoreboard) ResetScores(entry string) {
+	sb.mutex.Lock()
+	for _, obj := range sb.objectives {
+		delete(obj.scores, entry)
+	}
+	sb.mutex.Unlock()
+
+	// An empty objective name tells the client to remove the entry from every objective
+	sb.broadcast(&packets.PacketPlayOutUpdateScore{
+		EntityName: entry,
+		Action:     packets.RemoveScoreAction,
+	})
+}
+
+// ResolveScores returns a copy of the components with the value of every chat.ScoreComponent filled in,
+// using "*" as the name resolves the score of the viewer. The given components are left untouched.
+func (sb *scoreboard) ResolveScores(components []chat.Component, viewer Player) []chat.Component {
+	if components == nil {
+		return nil
+	}
+
+	resolved := make([]chat.Component, len(components))
+	for i, component := range components {
+		switch c := component.(type) {
+		case *chat.TextComponent:
+			text := *c
+			component = &text
+		case *chat.TranslatableComponent:
+			translatable := *c
+			translatable.With = sb.ResolveScores(c.With, viewer)
+			component = &translatable
+		case *chat.KeybindComponent:
+			keybind := *c
+			component = &keybind
+		case *chat.SelectorComponent:
+			selector := *c
+			component = &selector
+		case *chat.ScoreComponent:
+			score := *c
+			name := score.Score.Name
+			if name == "*" && viewer != nil {
+				name = viewer.GetUsername()
+			}
+
+			if obj := sb.GetObjective(score.Score.Objective); obj != nil {
+				if value, ok := obj.GetScore(name); ok {
+					score.Score.Value = strconv.Itoa(int(value))
+				}
+			}
+			component = &score
+		default:
+			resolved[i] = component
+			continue
+		}
+
+		component.SetExtra(sb.ResolveScores(component.GetExtra(), viewer))
+		resolved[i] = component
+	}
+	return resolved
+}
+
+func (sb *scoreboard) GetPlayers() []Player {
+	sb.mutex.RLock()
+	defer sb.mutex.RUnlock()
+
+	var players []Player
+	for _, player := range sb.players {
+		players = append(players, player)
+	}
+	return players
+}
+
+func (sb *scoreboard) addPlayer(player Player) error {
+	sb.mutex.Lock()
+	if _, ok := sb.players[player.GetUniqueID()]; ok {
+		sb.mutex.Unlock()
+		return nil
+	}
+	sb.players[player.GetUniqueID()] = player
+
+	var toSend []protocol.Packet
+	for _, obj := range sb.objectives {
+		toSend = append(toSend, obj.createPacket(packets.CreateObjectiveMode))
+		for entry, value := range obj.scores {
+			toSend = append(toSend, &packets.PacketPlayOutUpdateScore{
+				EntityName:    entry,
+				Action:        packets.UpdateScoreAction,
+				ObjectiveName: obj.name,
+				Value:         value,
+			})
+		}
+	}
+	for slot, obj := range sb.slots {
+		toSend = append(toSend, &packets.PacketPlayOutDisplayScoreboard{
+			Position:  slot,
+			ScoreName: obj.name,
+		})
+	}
+	for _, t := range sb.teams {
+		toSend = append(toSend, t.createPacket(packets.CreateTeamMode))
+	}
+	sb.mutex.Unlock()
+
+	for _, packet := range toSend {
+		if err := player.SendPacket(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sb *scoreboard) removePlayer(player Player) error {
+	sb.mutex.Lock()
+	if _, ok := sb.players[player.GetUniqueID()]; !ok {
+		sb.mutex.Unlock()
+		return nil
+	}
+	delete(sb.players, player.GetUniqueID())
+
+	var toSend []protocol.Packet
+	for _, obj := range sb.objectives {
+		toSend = append(toSend, &packets.PacketPlayOutScoreboardObjective{
+			Name: obj.name,
+			Mode: packets.RemoveObjectiveMode,
+		})
+	}
+	for _, t := range sb.teams {
+		toSend = append(toSend, &packets.PacketPlayOutTeams{
+			Name: t.name,
+			Mode: packets.RemoveTeamMode,
+		})
+	}
+	sb.mutex.Unlock()
+
+	for _, packet := range toSend {
+		if err := player.SendPacket(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sb *scoreboard) broadcast(toSend ...protocol.Packet) {
+	for _, player := range sb.GetPlayers() {
+		// Players that left the server are forgotten instead of written to
+		if player.GetServer().GetPlayer(player.GetUniqueID()) != player {
+			sb.mutex.Lock()
+			delete(sb.players, player.GetUniqueID())
+			sb.mutex.Unlock()
+			continue
+		}
+
+		for _, packet := range toSend {
+			if err := player.SendPacket(packet); err != nil {
+				log.Log.WithValues(
+					"name", player.GetUsername(),
+					"uuid", player.GetUniqueID(),
+				).Error(err, "failed to update scoreboard")
+				break
+			}
+		}
+	}
+}
+
+func (obj *objective) GetScoreboard() Scoreboard {
+	return obj.scoreboard
+}
+
+func (obj *objective) GetName() string {
+	return obj.name
+}
+
+func (obj *objective) SetDisplayName(displayName []chat.Component) {
+	obj.scoreboard.mutex.Lock()
+	obj.displayName = displayName
+	packet := obj.createPacket(packets.UpdateObjectiveMode)
+	obj.scoreboard.mutex.Unlock()
+
+	obj.scoreboard.broadcast(packet)
+}
+
+func (obj *objective) GetDisplayName() []chat.Component {
+	obj.scoreboard.mutex.RLock()
+	defer obj.scoreboard.mutex.RUnlock()
+	return obj.displayName
+}
+
+func (obj *objective) SetRenderType(renderType packets.ObjectiveRenderType) {
+	obj.scoreboard.mutex.Lock()
+	obj.renderType = renderType
+	packet := obj.createPacket(packets.UpdateObjectiveMode)
+	obj.scoreboard.mutex.Unlock()
+
+	obj.scoreboard.broadcast(packet)
+}
+
+func (obj *objective) GetRenderType() packets.ObjectiveRenderType {
+	obj.scoreboard.mutex.RLock()
+	defer obj.scoreboard.mutex.RUnlock()
+	return obj.renderType
+}
+
+func (obj *objective) SetScore(entry string, value int32) {
+	obj.scoreboard.mutex.Lock()
+	obj.scores[entry] = value
+	obj.scoreboard.mutex.Unlock()
+
+	obj.scoreboard.broadcast(&packets.PacketPlayOutUpdateScore{
+		EntityName:    entry,
+		Action:        packets.UpdateScoreAction,
+		ObjectiveName: obj.name,
+		Value:         value,
+	})
+}
+
+func (obj *objective) GetScore(entry string) (int32, bool) {
+	obj.scoreboard.mutex.RLock()
+	defer obj.scoreboard.mutex.RUnlock()
+	value, ok := obj.scores[entry]
+	return value, ok
+}
+
+func (obj *objective) GetScores() map[string]int32 {
+	obj.scoreboard.mutex.RLock()
+	defer obj.scoreboard.mutex.RUnlock()
+
+	var scores = make(map[string]int32, len(obj.scores))
+	for entry, value := range obj.scores {
+		scores[entry] = value
+	}
+	return scores
+}
+
+func (obj *objective) ResetScore(entry string) {
+	obj.scoreboard.mutex.Lock()
+	if _, ok := obj.scores[entry]; !ok {
+		obj.scoreboard.mutex.Unlock()
+		return
+	}
+	delete(obj.scores, entry)
+	obj.scoreboard.mutex.Unlock()
+
+	obj.scoreboard.broadcast(&packets.PacketPlayOutUpdateScore{
+		EntityName:    entry,
+		Action:        packets.RemoveScoreAction,
+		ObjectiveName: obj.name,
+	})
+}
+
+func (obj *objective) createPacket(mode packets.ObjectiveMode) *packets.PacketPlayOutScoreboardObjective {
+	return &packets.PacketPlayOutScoreboardObjective{
+		Name:        obj.name,
+		Mode:        mode,
+		DisplayName: obj.displayName,
+		RenderType:  obj.renderType,
+	}
+}
+
+func (t *team) GetScoreboard() Scoreboard {
+	return t.scoreboard
+}
+
+func (t *team) GetName() string {
+	return t.name
+}
+
+func (t *team) SetDisplayName(displayName []chat.Component) {
+	t.update(func() { t.displayName = displayName })
+}
+
+func (t *team) GetDisplayName() []chat.Component {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.displayName
+}
+
+func (t *team) SetPrefix(prefix []chat.Component) {
+	t.update(func() { t.prefix = prefix })
+}
+
+func (t *team) GetPrefix() []chat.Component {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.prefix
+}
+
+func (t *team) SetSuffix(suffix []chat.Component) {
+	t.update(func() { t.suffix = suffix })
+}
+
+func (t *team) GetSuffix() []chat.Component {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.suffix
+}
+
+func (t *team) SetColor(color *chat.Color) {
+	t.update(func() { t.color = color })
+}
+
+func (t *team) GetColor() *chat.Color {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.color
+}
+
+func (t *team) SetFriendlyFire(friendlyFire bool) {
+	t.update(func() { t.setFlag(packets.FriendlyFireTeamFlag, friendlyFire) })
+}
+
+func (t *team) HasFriendlyFire() bool {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.friendlyFlags&packets.FriendlyFireTeamFlag != 0
+}
+
+func (t *team) SetSeeInvisible(seeInvisible bool) {
+	t.update(func() { t.setFlag(packets.SeeInvisibleTeamFlag, seeInvisible) })
+}
+
+func (t *team) CanSeeInvisible() bool {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.friendlyFlags&packets.SeeInvisibleTeamFlag != 0
+}
+
+func (t *team) SetNameTagVisibility(visibility packets.NameTagVisibility) {
+	t.update(func() { t.nameTagVisibility = visibility })
+}
+
+func (t *team) GetNameTagVisibility() packets.NameTagVisibility {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.nameTagVisibility
+}
+
+func (t *team) SetCollisionRule(rule packets.CollisionRule) {
+	t.update(func() { t.collisionRule = rule })
+}
+
+func (t *team) GetCollisionRule() packets.CollisionRule {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.collisionRule
+}
+
+func (t *team) AddEntry(entry string) {
+	var toSend []protocol.Packet
+
+	t.scoreboard.mutex.Lock()
+	if current, ok := t.scoreboard.entries[entry]; ok {
+		if current == t {
+			t.scoreboard.mutex.Unlock()
+			return
+		}
+
+		// Entries can only belong to one team at a time
+		delete(current.entries, entry)
+		toSend = append(toSend, &packets.PacketPlayOutTeams{
+			Name:     current.name,
+			Mode:     packets.RemoveEntitiesTeamMode,
+			Entities: []string{entry},
+		})
+	}
+	t.entries[entry] = struct{}{}
+	t.scoreboard.entries[entry] = t
+	t.scoreboard.mutex.Unlock()
+
+	toSend = append(toSend, &packets.PacketPlayOutTeams{
+		Name:     t.name,
+		Mode:     packets.AddEntitiesTeamMode,
+		Entities: []string{entry},
+	})
+	t.scoreboard.broadcast(toSend...)
+}
+
+func (t *team) RemoveEntry(entry string) {
+	t.scoreboard.mutex.Lock()
+	if _, ok := t.entries[entry]; !ok {
+		t.scoreboard.mutex.Unlock()
+		return
+	}
+	delete(t.entries, entry)
+	delete(t.scoreboard.entries, entry)
+	t.scoreboard.mutex.Unlock()
+
+	t.scoreboard.broadcast(&packets.PacketPlayOutTeams{
+		Name:     t.name,
+		Mode:     packets.RemoveEntitiesTeamMode,
+		Entities: []string{entry},
+	})
+}
+
+func (t *team) HasEntry(entry string) bool {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	_, ok := t.entries[entry]
+	return ok
+}
+
+func (t *team) GetEntries() []string {
+	t.scoreboard.mutex.RLock()
+	defer t.scoreboard.mutex.RUnlock()
+	return t.getEntries()
+}
+
+func (t *team) update(fn func()) {
+	t.scoreboard.mutex.Lock()
+	fn()
+	packet := t.createPacket(packets.UpdateTeamMode)
+	t.scoreboard.mutex.Unlock()
+
+	t.scoreboard.broadcast(packet)
+}
+
+func (t *team) setFlag(flag packets.TeamFriendlyFlags, value bool) {
+	if value {
+		t.friendlyFlags |= flag
+	} else {
+		t.friendlyFlags &^= flag
+	}
+}
+
+func (t *team) getEntries() []string {
+	var entries []string
+	for entry := range t.entries {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+func (t *team) createPacket(mode packets.TeamMode) *packets.PacketPlayOutTeams {
+	packet := &packets.PacketPlayOutTeams{
+		Name:              t.name,
+		Mode:              mode,
+		DisplayName:       t.displayName,
+		Prefix:            t.prefix,
+		Suffix:            t.suffix,
+		FriendlyFlags:     t.friendlyFlags,
+		NameTagVisibility: t.nameTagVisibility,
+		CollisionRule:     t.collisionRule,
+		Color:             t.color,
+	}
+	if mode == packets.CreateTeamMode {
+		packet.Entities = t.getEntries()
+	}
+	return packet
+}
+
+func NewScoreboard() Scoreboard {
+	return &scoreboard{
+		objectives: make(map[string]*objective),
+		slots:      make(map[packets.DisplaySlot]*objective),
+		teams:      make(map[string]*team),
+		entries:    make(map[string]*team),
+		players:    make(map[uuid.UUID]Player),
+	}
+}
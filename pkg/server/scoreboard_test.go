@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/util/chat"
+	"testing"
+)
+
+func TestResolveScores(t *testing.T) {
+	sb := NewScoreboard()
+	obj, err := sb.RegisterObjective("kills", nil, packets.IntegerObjectiveRenderType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj.SetScore("Steve", 3)
+
+	score := &chat.ScoreComponent{Score: chat.Score{Name: "Steve", Objective: "kills"}}
+	message := []chat.Component{&chat.TextComponent{
+		Text:          "Kills: ",
+		BaseComponent: chat.BaseComponent{Extra: []chat.Component{score}},
+	}}
+
+	resolved := sb.ResolveScores(message, nil)
+	if got := resolved[0].GetExtra()[0].(*chat.ScoreComponent).Score.Value; got != "3" {
+		t.Errorf("ResolveScores() value = %q, want %q", got, "3")
+	}
+
+	if score.Score.Value != "" || message[0].GetExtra()[0] != score {
+		t.Errorf("ResolveScores() modified the given components")
+	}
+}
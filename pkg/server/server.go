@@ -4,19 +4,22 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/command"
 	"github.com/r4g3baby/mcserver/pkg/log"
+	"github.com/r4g3baby/mcserver/pkg/permission"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
 	"github.com/r4g3baby/mcserver/pkg/util/chat"
 	"github.com/r4g3baby/mcserver/pkg/util/eventbus"
 	"github.com/r4g3baby/mcserver/pkg/util/schematic"
-	"io"
 	"math"
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -24,27 +27,51 @@ import (
 var (
 	ErrServerRunning = errors.New("server already running")
 	ErrServerStopped = errors.New("server already stopped")
+	ErrNoWorldFile   = errors.New("world schematic file is not set")
 )
 
+const PermissionsFile = "permissions.json"
+
 type (
 	Server interface {
 		Start() error
 		Stop() error
+		Done() <-chan struct{}
+		GetTPS() (oneMinute, fiveMinutes, fifteenMinutes float64)
 
 		GetConfig() Config
 		GetWorld() World
+		SaveWorld() error
 
 		GetPlayerCount() int
 		GetPlayers() []Player
 		GetPlayer(uniqueID uuid.UUID) Player
+		GetPlayerByName(name string) Player
 		ForEachPlayer(fn func(player Player) bool)
+		// Broadcast sends the packet to every player the filter accepts, or all of them when it's nil
+		Broadcast(packet protocol.Packet, filter func(player Player) bool)
+
+		GetPermissions() permission.Manager
+
+		GetWhitelist() Whitelist
+		GetBanList() BanList
+		GetIPBanList() BanList
+
+		GetCommandDispatcher() command.Dispatcher
+		DispatchCommand(source command.Source, input string)
+
+		RegisterChannel(name string, handler ChannelHandler) error
+		UnregisterChannel(name string)
+		GetChannels() []string
+		getChannelHandler(name string) ChannelHandler
 
 		FireEvent(event string, args ...interface{})
 		On(event string, fn interface{}, priority ...eventbus.Priority) error
 		OnAsync(event string, fn interface{}) error
 
+		getConnectionLimiter() *connectionLimiter
 		createPlayer(conn Connection) (player Player, online bool)
-		removePlayer(uniqueID uuid.UUID)
+		removePlayer(conn Connection)
 	}
 
 	server struct {
@@ -53,17 +80,36 @@ type (
 
 		players  sync.Map
 		eventbus eventbus.EventBus
+		commands command.Dispatcher
+		channels sync.Map
+
+		permissions permission.Manager
+		whitelist   Whitelist
+		bans        BanList
+		ipBans      BanList
 
-		running  bool
-		shutdown func()
+		limiter *connectionLimiter
+
+		ticks uint64
+		tps   *tpsTracker
+
+		// lifecycle keeps concurrent starts and stops, like from the console and rcon, from overlapping
+		lifecycle sync.Mutex
+		running   bool
+		done      chan struct{}
+		shutdown  func()
 	}
 )
 
 func (server *server) Start() error {
+	server.lifecycle.Lock()
+	defer server.lifecycle.Unlock()
+
 	if server.running {
 		return ErrServerRunning
 	}
 	server.running = true
+	server.done = make(chan struct{})
 
 	bind := net.JoinHostPort(server.config.Host, strconv.Itoa(server.config.Port))
 	listener, err := net.Listen("tcp", bind)
@@ -76,6 +122,50 @@ func (server *server) Start() error {
 		"addr", listener.Addr(),
 	).Info("server listening for new connections")
 
+	var rcon *rconServer
+	if server.config.RCon.Enabled {
+		if rcon, err = listenRCon(server, server.config.RCon); err == nil {
+			log.Log.WithValues(
+				"addr", rcon.listener.Addr(),
+			).Info("rcon listening for new connections")
+		} else {
+			log.Log.Error(err, "failed to start rcon")
+		}
+	}
+
+	var query *queryServer
+	if server.config.Query.Enabled {
+		if query, err = listenQuery(server, server.config.Query); err == nil {
+			log.Log.WithValues(
+				"addr", query.conn.LocalAddr(),
+			).Info("query listening for new requests")
+		} else {
+			log.Log.Error(err, "failed to start query")
+		}
+	}
+
+	var metrics *metricsServer
+	if server.config.Metrics.Enabled {
+		if metrics, err = listenMetrics(server, server.config.Metrics); err == nil {
+			log.Log.WithValues(
+				"addr", metrics.listener.Addr(),
+			).Info("metrics listening for new connections")
+		} else {
+			log.Log.Error(err, "failed to start metrics")
+		}
+	}
+
+	var api *apiServer
+	if server.config.API.Enabled {
+		if api, err = listenAPI(server, server.config.API); err == nil {
+			log.Log.WithValues(
+				"addr", api.listener.Addr(),
+			).Info("api listening for new connections")
+		} else {
+			log.Log.Error(err, "failed to start api")
+		}
+	}
+
 	var wait sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 	server.shutdown = func() {
@@ -83,9 +173,61 @@ func (server *server) Start() error {
 		if err := listener.Close(); err != nil {
 			log.Log.Error(err, "got error while closing listener")
 		}
+		if rcon != nil {
+			if err := rcon.Close(); err != nil {
+				log.Log.Error(err, "got error while closing rcon listener")
+			}
+		}
+		if query != nil {
+			if err := query.Close(); err != nil {
+				log.Log.Error(err, "got error while closing query listener")
+			}
+		}
+		if metrics != nil {
+			if err := metrics.Close(); err != nil {
+				log.Log.Error(err, "got error while closing metrics listener")
+			}
+		}
+		if api != nil {
+			if err := api.Close(); err != nil {
+				log.Log.Error(err, "got error while closing api listener")
+			}
+		}
 		wait.Wait()
 	}
 
+	if rcon != nil {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			rcon.serve()
+		}()
+	}
+
+	if query != nil {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			query.serve()
+		}()
+	}
+
+	if metrics != nil {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			metrics.serve()
+		}()
+	}
+
+	if api != nil {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			api.serve()
+		}()
+	}
+
 	wait.Add(2)
 	go func() {
 		defer wait.Done()
@@ -103,7 +245,16 @@ func (server *server) Start() error {
 					continue
 				}
 
-				go server.handleClient(client)
+				if !server.limiter.allowConnection(client.RemoteAddr()) {
+					_ = client.Close()
+					continue
+				}
+
+				wait.Add(1)
+				go func() {
+					defer wait.Done()
+					server.handleClient(ctx, client)
+				}()
 			}
 		}
 	}()
@@ -111,7 +262,7 @@ func (server *server) Start() error {
 	go func() {
 		defer wait.Done()
 
-		ticker := time.NewTicker(time.Second)
+		ticker := time.NewTicker(TickDuration)
 		defer ticker.Stop()
 
 		for {
@@ -119,7 +270,7 @@ func (server *server) Start() error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				go server.sendKeepAlive()
+				server.tick()
 			}
 		}
 	}()
@@ -127,14 +278,18 @@ func (server *server) Start() error {
 	return nil
 }
 
+// Stop waits for every connection to close, so it can't be called from one of their goroutines
 func (server *server) Stop() error {
+	server.lifecycle.Lock()
+	defer server.lifecycle.Unlock()
+
 	if !server.running {
 		return ErrServerStopped
 	}
 
 	log.Log.Info("stopping server")
 
-	server.shutdown()
+	// Kicked before the shutdown closes every connection so players get a reason
 	server.ForEachPlayer(func(player Player) bool {
 		_ = player.Kick([]chat.Component{
 			&chat.TextComponent{
@@ -146,12 +301,28 @@ func (server *server) Stop() error {
 		})
 		return true
 	})
+	server.ForEachPlayer(func(player Player) bool {
+		_ = player.getConnection().Flush()
+		return true
+	})
+	server.shutdown()
 
 	server.running = false
+	close(server.done)
 
 	return nil
 }
 
+func (server *server) Done() <-chan struct{} {
+	server.lifecycle.Lock()
+	defer server.lifecycle.Unlock()
+	return server.done
+}
+
+func (server *server) GetTPS() (float64, float64, float64) {
+	return server.tps.get()
+}
+
 func (server *server) GetConfig() Config {
 	return server.config
 }
@@ -160,6 +331,45 @@ func (server *server) GetWorld() World {
 	return server.world
 }
 
+func (server *server) SaveWorld() error {
+	fileName := server.config.World.Schematic
+	if fileName == "" {
+		return ErrNoWorldFile
+	}
+
+	// Written next to the old file first so a failed save never leaves it half written
+	file, err := os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if err := server.world.Save(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Chmod(0644); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(file.Name(), fileName); err != nil {
+		return err
+	}
+
+	log.Log.WithValues(
+		"file", fileName,
+	).Info("saved world")
+	return nil
+}
+
 func (server *server) GetPlayerCount() int {
 	var count int
 	server.players.Range(func(_, _ interface{}) bool {
@@ -185,13 +395,44 @@ func (server *server) GetPlayer(uniqueID uuid.UUID) Player {
 	return nil
 }
 
+func (server *server) GetPlayerByName(name string) Player {
+	var result Player
+	server.ForEachPlayer(func(player Player) bool {
+		if strings.EqualFold(player.GetUsername(), name) {
+			result = player
+			return false
+		}
+		return true
+	})
+	return result
+}
+
 func (server *server) ForEachPlayer(fn func(player Player) bool) {
 	server.players.Range(func(_, value interface{}) bool {
 		return fn(value.(Player))
 	})
 }
 
+func (server *server) Broadcast(packet protocol.Packet, filter func(player Player) bool) {
+	// Each distinct protocol and compression setting only encodes the packet once
+	cache := make(frameCache)
+	server.ForEachPlayer(func(player Player) bool {
+		if filter != nil && !filter(player) {
+			return true
+		}
+
+		if err := player.getConnection().writePacket(packet, cache); err != nil && !errors.Is(err, net.ErrClosed) {
+			log.Log.WithValues(
+				"name", player.GetUsername(),
+				"uuid", player.GetUniqueID(),
+			).Error(err, "failed to broadcast packet")
+		}
+		return true
+	})
+}
+
 func (server *server) FireEvent(event string, args ...interface{}) {
+	defer observeDuration(eventHistogram.WithLabelValues(event), time.Now())
 	server.eventbus.Publish(event, args...)
 }
 
@@ -203,10 +444,50 @@ func (server *server) OnAsync(event string, fn interface{}) error {
 	return server.eventbus.SubscribeAsync(event, fn)
 }
 
+func (server *server) GetPermissions() permission.Manager {
+	return server.permissions
+}
+
+func (server *server) GetWhitelist() Whitelist {
+	return server.whitelist
+}
+
+func (server *server) GetBanList() BanList {
+	return server.bans
+}
+
+func (server *server) GetIPBanList() BanList {
+	return server.ipBans
+}
+
+func (server *server) GetCommandDispatcher() command.Dispatcher {
+	return server.commands
+}
+
+func (server *server) DispatchCommand(source command.Source, input string) {
+	if err := server.commands.Execute(source, input); err != nil {
+		if err := source.SendMessage([]chat.Component{
+			&chat.TextComponent{
+				Text: err.Error(),
+				BaseComponent: chat.BaseComponent{
+					Color: &chat.Red,
+				},
+			},
+		}); err != nil {
+			log.Log.Error(err, "failed to send command error")
+		}
+	}
+}
+
+func (server *server) getConnectionLimiter() *connectionLimiter {
+	return server.limiter
+}
+
 func (server *server) createPlayer(conn Connection) (Player, bool) {
 	value, loaded := server.players.LoadOrStore(conn.GetUniqueID(), newPlayer(conn))
 	player := value.(Player)
 	if !loaded {
+		server.world.addPlayer(player)
 		log.Log.WithValues(
 			"name", player.GetUsername(),
 			"uuid", player.GetUniqueID(),
@@ -215,30 +496,50 @@ func (server *server) createPlayer(conn Connection) (Player, bool) {
 	return player, loaded
 }
 
-func (server *server) removePlayer(uniqueID uuid.UUID) {
-	if player, ok := server.players.LoadAndDelete(uniqueID); ok {
-		player := player.(Player)
-		log.Log.WithValues(
-			"name", player.GetUsername(),
-			"uuid", player.GetUniqueID(),
-		).Info("player left the server")
+func (server *server) removePlayer(conn Connection) {
+	value, ok := server.players.Load(conn.GetUniqueID())
+	if !ok {
+		return
+	}
+
+	// A rejected duplicate login shares the uuid, it must not remove the player already online
+	player := value.(Player)
+	if player.getConnection() != conn {
+		return
 	}
+	server.players.Delete(conn.GetUniqueID())
+	server.world.removePlayer(player)
+
+	reason := conn.GetDisconnectReason()
+	log.Log.WithValues(
+		"name", player.GetUsername(),
+		"uuid", player.GetUniqueID(),
+		"reason", chat.ToLegacyText(reason),
+	).Info("player left the server")
+	server.FireEvent(OnPlayerQuitEvent, NewPlayerQuitEvent(player, reason))
 }
 
-func (server *server) handleClient(conn net.Conn) {
+func (server *server) handleClient(ctx context.Context, conn net.Conn) {
 	log.Log.WithValues(
 		"connection", conn.RemoteAddr(),
 	).V(1).Info("client connected")
 
-	connection := newConnection(conn, server)
+	connection := newConnection(ctx, conn, server)
+
+	// Stopping the server cancels the context, closing the socket unblocks the read below
+	go func() {
+		<-connection.Context().Done()
+		_ = connection.Close()
+	}()
+
 	for {
+		if err := connection.updateReadDeadline(); err != nil {
+			connection.handleReadError(err)
+			break
+		}
+
 		if err := connection.ReadPacket(); err != nil {
-			if !errors.Is(err, net.ErrClosed) && !errors.Is(err, io.EOF) {
-				log.Log.WithValues(
-					"connection", conn.RemoteAddr(),
-				).Error(err, "got error during packet read")
-				// todo: should we disconnect?
-			}
+			connection.handleReadError(err)
 			break
 		}
 	}
@@ -257,28 +558,55 @@ func (server *server) handleClient(conn net.Conn) {
 	).V(1).Info("client disconnected")
 }
 
+func (server *server) tick() {
+	defer observeDuration(tickHistogram, time.Now())
+	server.ticks++
+	server.ForEachPlayer(func(player Player) bool {
+		player.getConnection().requestFlush()
+		return true
+	})
+	if server.ticks%TicksPerSecond == 0 {
+		server.tps.update(time.Now())
+		go server.sendKeepAlive()
+	}
+	if server.ticks%(5*TicksPerSecond) == 0 {
+		go server.reloadLists()
+	}
+}
+
+func (server *server) reloadLists() {
+	for fileName, list := range map[string]interface{ reloadIfChanged() error }{
+		WhitelistFile:     server.whitelist,
+		BannedPlayersFile: server.bans,
+		BannedIPsFile:     server.ipBans,
+	} {
+		if err := list.reloadIfChanged(); err != nil {
+			log.Log.WithValues(
+				"file", fileName,
+			).Error(err, "failed to reload list")
+		}
+	}
+}
+
 func (server *server) sendKeepAlive() {
 	random := rand.New(rand.NewSource(time.Now().UnixNano()))
 	server.ForEachPlayer(func(player Player) bool {
 		if time.Since(player.GetLastKeepAliveTime()) >= 15*time.Second {
 			if !player.IsKeepAlivePending() {
-				if err := player.SendPacket(&packets.PacketPlayOutKeepAlive{
-					KeepAliveID: random.Int31n(math.MaxInt32),
-				}); err != nil {
+				keepAliveID := random.Int31n(math.MaxInt32)
+				var packet protocol.Packet = &packets.PacketPlayOutKeepAlive{KeepAliveID: keepAliveID}
+				if player.GetState() == protocol.Configuration {
+					packet = &packets.PacketConfigurationOutKeepAlive{KeepAliveID: keepAliveID}
+				}
+
+				if err := player.SendPacket(packet); err != nil {
 					log.Log.WithValues(
 						"name", player.GetUsername(),
 						"uuid", player.GetUniqueID(),
 					).Error(err, "failed to send keep alive packet")
 				}
 			} else {
-				if err := player.Kick([]chat.Component{
-					&chat.TextComponent{
-						Text: "Timed out",
-						BaseComponent: chat.BaseComponent{
-							Color: &chat.Red,
-						},
-					},
-				}); err != nil {
+				if err := player.Kick(timedOutReason); err != nil {
 					log.Log.WithValues(
 						"name", player.GetUsername(),
 						"uuid", player.GetUniqueID(),
@@ -299,6 +627,22 @@ func NewServer(config Config) Server {
 		}
 	}
 
+	if name := config.World.GameMode; name != "" {
+		if gameMode, err := protocol.ParseGameMode(name); err == nil {
+			world.SetDefaultGameMode(gameMode)
+		} else {
+			log.Log.Error(err, "invalid world game mode")
+		}
+	}
+
+	if name := config.World.Difficulty; name != "" {
+		if difficulty, err := protocol.ParseDifficulty(name); err == nil {
+			world.SetDifficulty(difficulty)
+		} else {
+			log.Log.Error(err, "invalid world difficulty")
+		}
+	}
+
 	if schemFileName := config.World.Schematic; schemFileName != "" {
 		if fileBytes, err := os.ReadFile(schemFileName); err == nil {
 			if schem, err := schematic.Read(bytes.NewBuffer(fileBytes)); err == nil {
@@ -307,14 +651,9 @@ func NewServer(config Config) Server {
 					"height", schem.GetHeight(),
 					"length", schem.GetLength(),
 					"size", schem.GetWidth()*schem.GetHeight()*schem.GetLength(),
+					"offset", schem.GetOffset(),
 				).Info("loading schematic")
-				for x := 0; x < schem.GetWidth(); x++ {
-					for y := 0; y < schem.GetHeight(); y++ {
-						for z := 0; z < schem.GetLength(); z++ {
-							world.SetBlock(x, y, z, schem.GetBlocks()[x][y][z])
-						}
-					}
-				}
+				world.Load(schem)
 				log.Log.Info("done loading schematic")
 			} else {
 				log.Log.Error(err, "failed to read schematic file")
@@ -324,10 +663,39 @@ func NewServer(config Config) Server {
 		}
 	}
 
-	return &server{
-		config:   config,
-		world:    world,
-		players:  sync.Map{},
-		eventbus: eventbus.New(),
+	permissions, err := permission.NewManager(permission.NewFileStorage(PermissionsFile))
+	if err != nil {
+		log.Log.Error(err, "failed to load permissions")
+	}
+
+	whitelist, err := NewWhitelist(WhitelistFile, config.Whitelist)
+	if err != nil {
+		log.Log.Error(err, "failed to load whitelist")
+	}
+
+	bans, err := NewBanList(BannedPlayersFile, false)
+	if err != nil {
+		log.Log.Error(err, "failed to load banned players")
+	}
+
+	ipBans, err := NewBanList(BannedIPsFile, true)
+	if err != nil {
+		log.Log.Error(err, "failed to load banned ips")
+	}
+
+	server := &server{
+		config:      config,
+		world:       world,
+		players:     sync.Map{},
+		eventbus:    eventbus.New(),
+		commands:    command.NewDispatcher(),
+		permissions: permissions,
+		whitelist:   whitelist,
+		bans:        bans,
+		ipBans:      ipBans,
+		limiter:     newConnectionLimiter(config.Limits),
+		tps:         newTPSTracker(),
 	}
+	registerDefaultCommands(server)
+	return server
 }
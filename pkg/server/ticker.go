@@ -0,0 +1,47 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	TicksPerSecond = 20
+	TickDuration   = time.Second / TicksPerSecond
+)
+
+// tpsTracker keeps exponential moving averages of the ticks per second over 1, 5 and 15 minutes
+type tpsTracker struct {
+	mutex    sync.RWMutex
+	last     time.Time
+	averages [3]float64
+}
+
+var tpsWindows = [3]float64{60, 5 * 60, 15 * 60}
+
+// update is called once every TicksPerSecond ticks
+func (tracker *tpsTracker) update(now time.Time) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if !tracker.last.IsZero() {
+		elapsed := now.Sub(tracker.last)
+		tps := math.Min(TicksPerSecond, TicksPerSecond*float64(time.Second)/float64(elapsed))
+		for i, window := range tpsWindows {
+			alpha := 1 - math.Exp(-elapsed.Seconds()/window)
+			tracker.averages[i] += alpha * (tps - tracker.averages[i])
+		}
+	}
+	tracker.last = now
+}
+
+func (tracker *tpsTracker) get() (float64, float64, float64) {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	return tracker.averages[0], tracker.averages[1], tracker.averages[2]
+}
+
+func newTPSTracker() *tpsTracker {
+	return &tpsTracker{averages: [3]float64{TicksPerSecond, TicksPerSecond, TicksPerSecond}}
+}
@@ -1,17 +1,25 @@
 package server
 
 import (
+	"github.com/google/uuid"
+	"github.com/r4g3baby/mcserver/pkg/log"
 	"github.com/r4g3baby/mcserver/pkg/protocol"
 	"github.com/r4g3baby/mcserver/pkg/protocol/blocks"
 	"github.com/r4g3baby/mcserver/pkg/protocol/packets"
+	"github.com/r4g3baby/mcserver/pkg/protocol/sounds"
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
 	"github.com/r4g3baby/mcserver/pkg/util/nbt"
 	"github.com/r4g3baby/mcserver/pkg/util/pools"
+	"github.com/r4g3baby/mcserver/pkg/util/schematic"
+	"io"
+	"math"
+	"sync"
 )
 
 const (
 	ChunkWidth    = 16
-	ChunkHeight   = 256
+	ChunkMinY     = -64
+	ChunkHeight   = 384
 	SectionWidth  = ChunkWidth
 	SectionHeight = 16
 	ChunkSections = ChunkHeight / SectionHeight
@@ -19,23 +27,43 @@ const (
 
 	MinBitsPerBlock    = 4
 	MaxBitsPerBlock    = 8
-	GlobalBitsPerBlock = 14
+	GlobalBitsPerBlock = 15
+	// LegacyGlobalBitsPerBlock is the global palette width before 1.16,
+	// those clients use it no matter what bits per block we send
+	LegacyGlobalBitsPerBlock = 14
 )
 
 type (
 	World interface {
 		GetName() string
 		GetDimension() protocol.Dimension
+		SetDifficulty(difficulty protocol.Difficulty)
+		GetDifficulty() protocol.Difficulty
+		SetDefaultGameMode(gameMode protocol.GameMode)
+		GetDefaultGameMode() protocol.GameMode
 		GetChunk(x, z int) Chunk
 		GetChunks() []Chunk
 		SetBlock(x, y, z int, block string)
 		GetBlock(x, y, z int) string
+		Load(schem schematic.Schematic)
+		Save(writer io.Writer) error
 		SendChunks(player Player) error
+		addPlayer(player Player)
+		removePlayer(player Player)
+		GetPlayers() []Player
+		GetNearbyPlayers(position Position, radius float64) []Player
+		PlaySound(sound string, category sounds.Category, position Position, volume, pitch float32)
+		SpawnParticle(effect ParticleEffect)
+	}
+
+	Position struct {
+		X, Y, Z float64
 	}
 
 	Chunk interface {
 		GetX() int
 		GetZ() int
+		// GetSection takes the section y, which is negative below zero, sections are indexed from ChunkMinY
 		GetSection(y int) ChunkSection
 		GetSections() [ChunkSections]ChunkSection
 		SetBlock(x, y, z int, block string)
@@ -65,6 +93,11 @@ type (
 		name      string
 		dimension protocol.Dimension
 		chunks    []Chunk
+
+		mutex           sync.RWMutex
+		difficulty      protocol.Difficulty
+		defaultGameMode protocol.GameMode
+		players         map[uuid.UUID]Player
 	}
 
 	chunk struct {
@@ -90,6 +123,42 @@ func (world *world) GetDimension() protocol.Dimension {
 	return world.dimension
 }
 
+func (world *world) SetDifficulty(difficulty protocol.Difficulty) {
+	world.mutex.Lock()
+	world.difficulty = difficulty
+	world.mutex.Unlock()
+
+	for _, player := range world.GetPlayers() {
+		if err := player.SendPacket(&packets.PacketPlayOutServerDifficulty{
+			Difficulty: uint8(difficulty),
+			Locked:     true,
+		}); err != nil {
+			log.Log.WithValues(
+				"name", player.GetUsername(),
+				"uuid", player.GetUniqueID(),
+			).Error(err, "failed to update difficulty")
+		}
+	}
+}
+
+func (world *world) GetDifficulty() protocol.Difficulty {
+	world.mutex.RLock()
+	defer world.mutex.RUnlock()
+	return world.difficulty
+}
+
+func (world *world) SetDefaultGameMode(gameMode protocol.GameMode) {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	world.defaultGameMode = gameMode
+}
+
+func (world *world) GetDefaultGameMode() protocol.GameMode {
+	world.mutex.RLock()
+	defer world.mutex.RUnlock()
+	return world.defaultGameMode
+}
+
 func (world *world) GetChunk(x, z int) Chunk {
 	for _, chunk := range world.chunks {
 		if chunk.GetX() == x && chunk.GetZ() == z {
@@ -114,75 +183,120 @@ func (world *world) GetBlock(x, y, z int) string {
 	return world.GetChunk(x>>4, z>>4).GetBlock(mod(x, 16), y, mod(z, 16))
 }
 
-func (world *world) SendChunks(player Player) error {
-	var biomes []int32
-	for i := 0; i < 1024; i++ {
-		biomes = append(biomes, 127)
+// Load places the schematic with its first block at the offset, which is where Save found it
+func (world *world) Load(schem schematic.Schematic) {
+	offset := schem.GetOffset()
+	for x := 0; x < schem.GetWidth(); x++ {
+		for y := 0; y < schem.GetHeight(); y++ {
+			for z := 0; z < schem.GetLength(); z++ {
+				world.SetBlock(offset[0]+x, offset[1]+y, offset[2]+z, schem.GetBlocks()[x][y][z])
+			}
+		}
 	}
+}
 
-	for _, chunk := range world.chunks {
-		if err := func(data *bytes.Buffer) error {
-			defer pools.Buffer.Put(data)
+// Save writes every non air block as a schematic starting at the world origin so it lines
+// up when loaded back, blocks below the origin extend it and are recorded in the offset
+func (world *world) Save(writer io.Writer) error {
+	var min, max [3]int
+	world.forEachBlock(func(pos [3]int, _ string) {
+		for i := range pos {
+			if pos[i] < min[i] {
+				min[i] = pos[i]
+			}
+			if pos[i]+1 > max[i] {
+				max[i] = pos[i] + 1
+			}
+		}
+	})
+
+	blocks := make([][][]string, max[0]-min[0])
+	for x := range blocks {
+		blocks[x] = make([][]string, max[1]-min[1])
+		for y := range blocks[x] {
+			blocks[x][y] = make([]string, max[2]-min[2])
+			for z := range blocks[x][y] {
+				blocks[x][y][z] = "minecraft:air"
+			}
+		}
+	}
 
-			mask := 0
-			for sectionY, section := range chunk.GetSections() {
-				if section != nil && !section.IsEmpty() {
-					mask |= 1 << sectionY
+	world.forEachBlock(func(pos [3]int, block string) {
+		blocks[pos[0]-min[0]][pos[1]-min[1]][pos[2]-min[2]] = block
+	})
 
-					if err := data.WriteUint16(SectionVolume); err != nil {
-						return err
-					}
+	return schematic.Write(writer, schematic.New(world.name, "mcserver", min, blocks))
+}
 
-					bitsPerBlock := section.GetBlocks().GetBitsPerValue()
-					if err := data.WriteUint8(uint8(bitsPerBlock)); err != nil {
-						return err
-					}
+func (world *world) forEachBlock(fn func(pos [3]int, block string)) {
+	for _, chunk := range world.chunks {
+		for sectionY, section := range chunk.GetSections() {
+			if section == nil || section.IsEmpty() {
+				continue
+			}
 
-					var blockData []uint64
-					if bitsPerBlock == GlobalBitsPerBlock {
-						// Since we depend on the player protocol version we have to create the blockData here
-						// This is a bit more expensive but it's the best approach I can think of atm
-						blocksArray := bytes.NewPackedArray(bitsPerBlock, SectionVolume)
-						for i := 0; i < section.GetBlocks().GetCapacity(); i++ {
-							block := section.GetPalette().GetBlock(section.GetBlocks().Get(i))
-							blocksArray.Set(i, blocks.GetBlockID(block, player.GetProtocol()))
+			for x := 0; x < SectionWidth; x++ {
+				for y := 0; y < SectionHeight; y++ {
+					for z := 0; z < SectionWidth; z++ {
+						if block := section.GetBlock(x, y, z); block != "minecraft:air" {
+							fn([3]int{chunk.GetX()*ChunkWidth + x, ChunkMinY + sectionY*SectionHeight + y, chunk.GetZ()*ChunkWidth + z}, block)
 						}
+					}
+				}
+			}
+		}
+	}
+}
 
-						blockData = blocksArray.GetData()
-					} else {
-						if err := data.WriteVarInt(int32(section.GetPalette().GetLength())); err != nil {
-							return err
-						}
-						for _, block := range section.GetPalette().GetBlocks() {
-							id := blocks.GetBlockID(block, player.GetProtocol())
-							if err := data.WriteVarInt(int32(id)); err != nil {
-								return err
-							}
-						}
+func (world *world) SendChunks(player Player) error {
+	proto := player.GetProtocol()
 
-						blockData = section.GetBlocks().GetData()
-					}
+	// Clients before 1.17 only know about the blocks between 0 and 256
+	minSection, sections := 0, 256/SectionHeight
+	if proto >= protocol.V1_17 {
+		minSection, sections = int(world.dimension.MinY)>>4, int(world.dimension.Height)/SectionHeight
+	}
 
-					if err := data.WriteVarInt(int32(len(blockData))); err != nil {
-						return err
-					}
-					for _, value := range blockData {
-						if err := data.WriteUint64(value); err != nil {
+	// Biomes were sent for every 4x4x4 area of the chunk until 1.18 moved them into each section
+	var biomes []int32
+	if proto < protocol.V1_18 {
+		for i := 0; i < sections*(SectionWidth/4)*(SectionHeight/4)*(SectionWidth/4); i++ {
+			biomes = append(biomes, 127)
+		}
+	}
+
+	light := newChunkLight(world.dimension, sections)
+	for _, chunk := range world.chunks {
+		if err := func(data *bytes.Buffer) error {
+			defer pools.Buffer.Put(data)
+
+			mask := 0
+			for i := 0; i < sections; i++ {
+				section := getSection(chunk, minSection+i)
+				if section == nil || section.IsEmpty() {
+					if proto >= protocol.V1_18 {
+						if err := writeEmptySection(data, proto); err != nil {
 							return err
 						}
 					}
+					continue
+				}
+
+				mask |= 1 << i
+				if err := writeSection(data, section, proto); err != nil {
+					return err
 				}
 			}
 
 			return player.SendPacket(&packets.PacketPlayOutChunkData{
-				ChunkX:        int32(chunk.GetX()),
-				ChunkZ:        int32(chunk.GetZ()),
-				FullChunk:     true,
-				PrimaryBit:    int32(mask),
-				Heightmaps:    nbt.CompoundTag{},
-				Biomes:        biomes,
-				Data:          data.Bytes(),
-				BlockEntities: []nbt.Tag{},
+				ChunkX:     int32(chunk.GetX()),
+				ChunkZ:     int32(chunk.GetZ()),
+				FullChunk:  true,
+				PrimaryBit: int32(mask),
+				Heightmaps: nbt.CompoundTag{},
+				Biomes:     biomes,
+				Data:       data.Bytes(),
+				Light:      light,
 			})
 		}(pools.Buffer.Get(nil)); err != nil {
 			return err
@@ -192,6 +306,198 @@ func (world *world) SendChunks(player Player) error {
 	return nil
 }
 
+// getSection returns the section at the given section y without creating it
+func getSection(chunk Chunk, y int) ChunkSection {
+	index := y - ChunkMinY/SectionHeight
+	if index < 0 || index >= ChunkSections {
+		return nil
+	}
+	return chunk.GetSections()[index]
+}
+
+func writeSection(data *bytes.Buffer, section ChunkSection, proto protocol.Protocol) error {
+	if err := data.WriteUint16(SectionVolume); err != nil {
+		return err
+	}
+
+	bitsPerBlock := section.GetBlocks().GetBitsPerValue()
+	global := bitsPerBlock > MaxBitsPerBlock
+	if global {
+		bitsPerBlock = globalBitsPerBlock(proto)
+	}
+
+	if err := data.WriteUint8(uint8(bitsPerBlock)); err != nil {
+		return err
+	}
+
+	var blockData []uint64
+	if global {
+		// Since we depend on the player protocol version we have to create the blockData here
+		// This is a bit more expensive but it's the best approach I can think of atm
+		blocksArray := bytes.NewPackedArray(bitsPerBlock, SectionVolume)
+		for i := 0; i < section.GetBlocks().GetCapacity(); i++ {
+			block := section.GetPalette().GetBlock(section.GetBlocks().Get(i))
+			blocksArray.Set(i, blocks.GetBlockID(block, proto))
+		}
+
+		blockData = blocksArray.GetData()
+	} else {
+		if err := data.WriteVarInt(int32(section.GetPalette().GetLength())); err != nil {
+			return err
+		}
+		for _, block := range section.GetPalette().GetBlocks() {
+			id := blocks.GetBlockID(block, proto)
+			if err := data.WriteVarInt(int32(id)); err != nil {
+				return err
+			}
+		}
+
+		blockData = section.GetBlocks().GetData()
+	}
+
+	if err := data.WriteVarInt(int32(len(blockData))); err != nil {
+		return err
+	}
+	for _, value := range blockData {
+		if err := data.WriteUint64(value); err != nil {
+			return err
+		}
+	}
+
+	if proto >= protocol.V1_18 {
+		return writeSectionBiomes(data)
+	}
+	return nil
+}
+
+// globalBitsPerBlock returns how many bits the global palette ids of the protocol take
+func globalBitsPerBlock(proto protocol.Protocol) int {
+	if proto < protocol.V1_16 {
+		return LegacyGlobalBitsPerBlock
+	}
+	return GlobalBitsPerBlock
+}
+
+// writeEmptySection writes a section filled with air, 1.18+ clients expect every section to be sent
+func writeEmptySection(data *bytes.Buffer, proto protocol.Protocol) error {
+	if err := data.WriteUint16(0); err != nil {
+		return err
+	}
+
+	if err := writeSingleValue(data, int32(blocks.GetBlockID("minecraft:air", proto))); err != nil {
+		return err
+	}
+
+	return writeSectionBiomes(data)
+}
+
+func writeSectionBiomes(data *bytes.Buffer) error {
+	return writeSingleValue(data, 127)
+}
+
+// writeSingleValue writes a paletted container that holds a single value, which needs no data
+func writeSingleValue(data *bytes.Buffer, value int32) error {
+	if err := data.WriteUint8(0); err != nil {
+		return err
+	}
+
+	if err := data.WriteVarInt(value); err != nil {
+		return err
+	}
+
+	return data.WriteVarInt(0)
+}
+
+// newChunkLight lights up every section as if it was under the open sky, there's no light engine.
+// Light is also sent for the section below and above the world, hence the two extra sections.
+func newChunkLight(dimension protocol.Dimension, sections int) packets.ChunkLight {
+	mask := int64(1)<<(sections+2) - 1
+
+	light := packets.ChunkLight{
+		TrustEdges:          true,
+		EmptyBlockLightMask: mask,
+	}
+
+	if !dimension.HasSkylight {
+		light.EmptySkyLightMask = mask
+		return light
+	}
+
+	fullBright := make([]byte, 2048)
+	for i := range fullBright {
+		fullBright[i] = 0xFF
+	}
+
+	light.SkyLightMask = mask
+	for i := 0; i < sections+2; i++ {
+		light.SkyLight = append(light.SkyLight, fullBright)
+	}
+	return light
+}
+
+func (world *world) addPlayer(player Player) {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	world.players[player.GetUniqueID()] = player
+}
+
+func (world *world) removePlayer(player Player) {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	delete(world.players, player.GetUniqueID())
+}
+
+func (world *world) GetPlayers() []Player {
+	world.mutex.RLock()
+	defer world.mutex.RUnlock()
+
+	var players []Player
+	for _, player := range world.players {
+		// Players still logging in can't receive play packets yet
+		if player.GetState() == protocol.Play {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+func (world *world) GetNearbyPlayers(position Position, radius float64) []Player {
+	var players []Player
+	for _, player := range world.GetPlayers() {
+		if player.GetPosition().Distance(position) <= radius {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+func (world *world) PlaySound(sound string, category sounds.Category, position Position, volume, pitch float32) {
+	for _, player := range world.GetNearbyPlayers(position, getSoundRange(volume)) {
+		if err := player.PlaySound(sound, category, position, volume, pitch); err != nil {
+			log.Log.WithValues(
+				"name", player.GetUsername(),
+				"uuid", player.GetUniqueID(),
+			).Error(err, "failed to play sound")
+		}
+	}
+}
+
+func (world *world) SpawnParticle(effect ParticleEffect) {
+	for _, player := range world.GetNearbyPlayers(effect.Position, effect.getRange()) {
+		if err := player.SpawnParticle(effect); err != nil {
+			log.Log.WithValues(
+				"name", player.GetUsername(),
+				"uuid", player.GetUniqueID(),
+			).Error(err, "failed to spawn particle")
+		}
+	}
+}
+
+func (position Position) Distance(other Position) float64 {
+	dx, dy, dz := position.X-other.X, position.Y-other.Y, position.Z-other.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
 func (chunk *chunk) GetX() int {
 	return chunk.x
 }
@@ -205,7 +511,8 @@ func (chunk *chunk) GetSections() [ChunkSections]ChunkSection {
 }
 
 func (chunk *chunk) GetSection(y int) ChunkSection {
-	if y < 0 || y > ChunkSections {
+	y -= ChunkMinY / SectionHeight
+	if y < 0 || y >= ChunkSections {
 		return nil
 	}
 
@@ -316,8 +623,11 @@ func (sPalette *sectionPalette) GetBitsPerBlock() int {
 
 func NewWorld(name string, dimension protocol.Dimension) World {
 	return &world{
-		name:      name,
-		dimension: dimension,
+		name:            name,
+		dimension:       dimension,
+		difficulty:      protocol.Easy,
+		defaultGameMode: protocol.Survival,
+		players:         make(map[uuid.UUID]Player),
 	}
 }
 
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"fmt"
+	"github.com/r4g3baby/mcserver/pkg/protocol"
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteSectionGlobalPalette(t *testing.T) {
+	section := NewWorld("test", protocol.Overworld).GetChunk(0, 0).GetSection(0)
+	// More than 256 palette entries is what pushes a section to the global palette
+	instruments := []string{"harp", "basedrum", "snare", "hat", "bass", "flute"}
+	for i := 0; i < 300; i++ {
+		block := fmt.Sprintf("minecraft:note_block[instrument=%s,note=%d,powered=%t]", instruments[i/50], i%25, i/25%2 == 0)
+		section.SetBlock(i%16, i/256, (i/16)%16, block)
+	}
+
+	tests := []struct {
+		proto protocol.Protocol
+		bits  int
+	}{
+		{protocol.V1_13, LegacyGlobalBitsPerBlock},
+		{protocol.V1_15_2, LegacyGlobalBitsPerBlock},
+		{protocol.V1_16, GlobalBitsPerBlock},
+		{protocol.V1_20_2, GlobalBitsPerBlock},
+	}
+	for _, test := range tests {
+		data := bytes.NewBuffer(nil)
+		if err := writeSection(data, section, test.proto); err != nil {
+			t.Fatal(err)
+		}
+
+		if bits := int(data.Bytes()[2]); bits != test.bits {
+			t.Errorf("%d: wrote %d bits per block, want %d", test.proto, bits, test.bits)
+		}
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	blocks := map[[3]int]string{
+		{0, 0, 0}:     "minecraft:stone",
+		{3, 70, -2}:   "minecraft:oak_planks",
+		{-20, -64, 5}: "minecraft:bedrock",
+		{7, -1, 17}:   "minecraft:deepslate[axis=y]",
+	}
+
+	world := NewWorld("test", protocol.Overworld)
+	for pos, block := range blocks {
+		world.SetBlock(pos[0], pos[1], pos[2], block)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "world.schem")
+	file, err := os.Create(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := world.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loaded by the server like on startup, every block has to end up where it was saved
+	loaded := newTestServer(t, Config{World: WorldConf{Schematic: fileName}}).GetWorld()
+	for pos, block := range blocks {
+		if got := loaded.GetBlock(pos[0], pos[1], pos[2]); got != block {
+			t.Errorf("block at %v = %s, want %s", pos, got, block)
+		}
+	}
+
+	if got := loaded.GetBlock(0, 1, 0); got != "minecraft:air" {
+		t.Errorf("block at [0 1 0] = %s, want minecraft:air", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"io"
 	"math"
+	"unicode/utf8"
 )
 
 type Buffer struct {
@@ -21,12 +22,12 @@ func (buffer *Buffer) ReadVarInt() (int32, error) {
 			return result, err
 		}
 
-		result |= int32(read&0x7F) << (7 * numRead)
-
 		if numRead >= 5 {
-			return result, errors.New("VarInt too big")
+			return 0, errors.New("VarInt too big")
 		}
 
+		result |= int32(read&0x7F) << (7 * numRead)
+
 		if (read & 0x80) != 0x80 {
 			break
 		}
@@ -42,12 +43,12 @@ func (buffer *Buffer) ReadVarLong() (int64, error) {
 			return result, err
 		}
 
-		result |= int64(read&0x7F) << (7 * numRead)
-
 		if numRead >= 10 {
-			return result, errors.New("VarLong too big")
+			return 0, errors.New("VarLong too big")
 		}
 
+		result |= int64(read&0x7F) << (7 * numRead)
+
 		if (read & 0x80) != 0x80 {
 			break
 		}
@@ -61,23 +62,39 @@ func (buffer *Buffer) ReadUtf(maxLength int) (string, error) {
 		return "", err
 	}
 
-	if length < 1 || int(length) > (maxLength*4)+3 {
+	if length < 0 || int(length) > (maxLength*4)+3 {
 		return "", errors.New("the received encoded string bytes length is invalid")
 	}
 
 	var str = make([]byte, length)
-	n, err := io.ReadFull(buffer, str)
-	if err != nil {
+	if _, err := io.ReadFull(buffer, str); err != nil {
 		return "", err
 	}
 
-	if n > maxLength {
+	if utf8.RuneCount(str) > maxLength {
 		return "", errors.New("the received string length is longer than maximum allowed")
 	}
 
 	return string(str), nil
 }
 
+func (buffer *Buffer) ReadByteArray(maxLength int) ([]byte, error) {
+	length, err := buffer.ReadVarInt()
+	if err != nil {
+		return nil, err
+	}
+
+	if length < 0 || int(length) > maxLength {
+		return nil, errors.New("the received byte array length is invalid")
+	}
+
+	var value = make([]byte, length)
+	if _, err := io.ReadFull(buffer, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 func (buffer *Buffer) ReadUUID() (uuid.UUID, error) {
 	var uuidBytes = make([]byte, 16)
 	_, err := io.ReadFull(buffer, uuidBytes)
@@ -105,7 +122,7 @@ func (buffer *Buffer) ReadInt8() (int8, error) {
 
 func (buffer *Buffer) ReadUint8() (uint8, error) {
 	var value = make([]byte, 1)
-	_, err := buffer.Read(value)
+	_, err := io.ReadFull(buffer, value)
 	if err != nil {
 		return 0, err
 	}
@@ -122,7 +139,7 @@ func (buffer *Buffer) ReadInt16() (int16, error) {
 
 func (buffer *Buffer) ReadUint16() (uint16, error) {
 	var value = make([]byte, 2)
-	_, err := buffer.Read(value)
+	_, err := io.ReadFull(buffer, value)
 	if err != nil {
 		return 0, err
 	}
@@ -139,7 +156,7 @@ func (buffer *Buffer) ReadInt32() (int32, error) {
 
 func (buffer *Buffer) ReadUint32() (uint32, error) {
 	var value = make([]byte, 4)
-	_, err := buffer.Read(value)
+	_, err := io.ReadFull(buffer, value)
 	if err != nil {
 		return 0, err
 	}
@@ -156,7 +173,7 @@ func (buffer *Buffer) ReadInt64() (int64, error) {
 
 func (buffer *Buffer) ReadUint64() (uint64, error) {
 	var value = make([]byte, 8)
-	_, err := buffer.Read(value)
+	_, err := io.ReadFull(buffer, value)
 	if err != nil {
 		return 0, err
 	}
@@ -180,25 +197,29 @@ func (buffer *Buffer) ReadFloat64() (float64, error) {
 }
 
 func (buffer *Buffer) WriteVarInt(value int32) error {
-	for value >= 0x80 {
-		err := buffer.WriteByte(byte(value) | 0x80)
+	// Negative values are encoded through their two's complement, using every byte
+	unsigned := uint32(value)
+	for unsigned >= 0x80 {
+		err := buffer.WriteByte(byte(unsigned) | 0x80)
 		if err != nil {
 			return err
 		}
-		value >>= 7
+		unsigned >>= 7
 	}
-	return buffer.WriteByte(byte(value))
+	return buffer.WriteByte(byte(unsigned))
 }
 
 func (buffer *Buffer) WriteVarLong(value int64) error {
-	for value >= 0x80 {
-		err := buffer.WriteByte(byte(value) | 0x80)
+	// Negative values are encoded through their two's complement, using every byte
+	unsigned := uint64(value)
+	for unsigned >= 0x80 {
+		err := buffer.WriteByte(byte(unsigned) | 0x80)
 		if err != nil {
 			return err
 		}
-		value >>= 7
+		unsigned >>= 7
 	}
-	return buffer.WriteByte(byte(value))
+	return buffer.WriteByte(byte(unsigned))
 }
 
 func (buffer *Buffer) WriteUtf(value string, maxLength int) error {
@@ -214,6 +235,18 @@ func (buffer *Buffer) WriteUtf(value string, maxLength int) error {
 	return err
 }
 
+func (buffer *Buffer) WriteByteArray(value []byte, maxLength int) error {
+	if len(value) > maxLength {
+		return errors.New("byte array too big")
+	}
+	err := buffer.WriteVarInt(int32(len(value)))
+	if err != nil {
+		return err
+	}
+	_, err = buffer.Write(value)
+	return err
+}
+
 func (buffer *Buffer) WriteUUID(uuid uuid.UUID) error {
 	err := buffer.WriteUint64(binary.BigEndian.Uint64(uuid[:8]))
 	if err != nil {
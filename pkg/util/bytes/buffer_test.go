@@ -66,6 +66,42 @@ func TestBuffer_Utf(t *testing.T) {
 	}
 }
 
+func TestBuffer_EmptyUtf(t *testing.T) {
+	t.Cleanup(cleanup)
+	var want = ""
+
+	if err := buffer.WriteUtf(want, 16); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := buffer.ReadUtf(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("Utf was incorrect, got: %s, want: %s.", got, want)
+	}
+}
+
+func TestBuffer_ByteArray(t *testing.T) {
+	t.Cleanup(cleanup)
+	var want = []byte{0xCA, 0xFE, 0xBA, 0xBE}
+
+	if err := buffer.WriteByteArray(want, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := buffer.ReadByteArray(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ByteArray was incorrect, got: %x, want: %x.", got, want)
+	}
+}
+
 func TestBuffer_UUID(t *testing.T) {
 	t.Cleanup(cleanup)
 	var want, err = uuid.NewRandom()
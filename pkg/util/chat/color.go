@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 )
 
 type Color struct {
@@ -46,8 +47,22 @@ var (
 	}
 )
 
+// StripColors removes every legacy color and formatting code from the text.
+func StripColors(text string) string {
+	var result strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if string(runes[i]) == ColorChar && i+1 < len(runes) {
+			i++
+			continue
+		}
+		result.WriteRune(runes[i])
+	}
+	return result.String()
+}
+
 func (color *Color) String() string {
-	if color.Hex != "" {
+	if len(color.Hex) == 6 {
 		return fmt.Sprint("#", color.Hex)
 	} else if color.Name != "" {
 		return color.Name
@@ -57,7 +72,7 @@ func (color *Color) String() string {
 }
 
 func (color *Color) RGB() (r int64, g int64, b int64) {
-	if color.Hex != "" {
+	if len(color.Hex) == 6 {
 		r, _ = strconv.ParseInt(color.Hex[:2], 16, 10)
 		g, _ = strconv.ParseInt(color.Hex[2:4], 16, 18)
 		b, _ = strconv.ParseInt(color.Hex[4:], 16, 10)
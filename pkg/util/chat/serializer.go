@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -69,6 +70,8 @@ func (s *Serializer) ToLegacyText(components []Component) string {
 		switch t := c.(type) {
 		case *TextComponent:
 			text.WriteString(t.Text)
+		case *ScoreComponent:
+			text.WriteString(t.Score.Value)
 		}
 
 		text.WriteString(s.ToLegacyText(c.GetExtra()))
@@ -77,7 +80,7 @@ func (s *Serializer) ToLegacyText(components []Component) string {
 }
 
 func (s *Serializer) ToJSON(components []Component) ([]byte, error) {
-	var array []map[string]interface{}
+	var array = make([]map[string]interface{}, 0, len(components))
 	for _, c := range components {
 		var obj = make(map[string]interface{})
 		if err := s.encode(obj, c); err != nil {
@@ -289,7 +292,7 @@ func (s *Serializer) decodeTranslatable(obj map[string]interface{}) (Component,
 
 	var with []Component
 	if withArray, ok := obj["with"]; ok {
-		withArray, ok := withArray.([]map[string]interface{})
+		withArray, ok := withArray.([]interface{})
 		if !ok {
 			return nil, errors.New("with key must be a array")
 		}
@@ -414,6 +417,9 @@ func (s *Serializer) decodeComponent(obj map[string]interface{}, c Component) (C
 		var color Color
 		if strings.HasPrefix(colorStr, "#") {
 			color.Hex = strings.TrimPrefix(colorStr, "#")
+			if _, err := strconv.ParseUint(color.Hex, 16, 24); err != nil || len(color.Hex) != 6 {
+				return nil, fmt.Errorf("invalid hex color %q", colorStr)
+			}
 			if s.ForceLegacyColors {
 				color = FindNearest(color)
 			}
@@ -487,7 +493,7 @@ func (s *Serializer) decodeComponent(obj map[string]interface{}, c Component) (C
 	}
 
 	if extraArray, ok := obj["extra"]; ok {
-		extraArray, ok := extraArray.([]map[string]interface{})
+		extraArray, ok := extraArray.([]interface{})
 		if !ok {
 			return nil, errors.New("extra key must be a array")
 		}
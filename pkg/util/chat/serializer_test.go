@@ -0,0 +1,34 @@
+package chat
+
+import (
+	"testing"
+)
+
+func FuzzFromJSON(f *testing.F) {
+	for _, seed := range []string{
+		`"plain"`,
+		`{"text":"hello","color":"red","bold":true}`,
+		`[{"text":"a","color":"#c33131"},{"translate":"chat.type.text","with":["b",{"text":"c"}]}]`,
+		`{"text":"","extra":[{"keybind":"key.jump"},{"selector":"@p"}]}`,
+		`{"score":{"name":"a","objective":"b","value":"1"},"clickEvent":{"action":"run_command","value":"/help"}}`,
+		`{"text":"hover","hoverEvent":{"action":"show_text","contents":{"text":"tip"}},"insertion":"x"}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		components, err := FromJSON(input)
+		if err != nil {
+			return
+		}
+
+		// Whatever was accepted must survive being encoded and decoded again
+		encoded, err := ToJSON(components)
+		if err != nil {
+			t.Fatalf("ToJSON() error = %v", err)
+		}
+		if _, err := FromJSON(encoded); err != nil {
+			t.Fatalf("FromJSON() error = %v for %s", err, encoded)
+		}
+	})
+}
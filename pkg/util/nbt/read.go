@@ -2,12 +2,22 @@ package nbt
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/klauspost/compress/gzip"
 	"io"
 	"math"
 )
 
+// MaxDepth is how deep lists and compounds can be nested, the same limit vanilla uses
+const MaxDepth = 512
+
+var (
+	ErrMaxDepth        = errors.New("nbt tags are nested too deep")
+	ErrNegativeLength  = errors.New("nbt tag has a negative length")
+	ErrMissingListType = errors.New("nbt list has elements but no type")
+)
+
 func ReadCompressed(reader io.Reader) (string, Tag, error) {
 	gzipReader, err := gzip.NewReader(reader)
 	if err != nil {
@@ -20,6 +30,24 @@ func ReadCompressed(reader io.Reader) (string, Tag, error) {
 }
 
 func Read(reader io.Reader) (string, Tag, error) {
+	return readNamed(reader, 0)
+}
+
+// ReadUnnamed reads a tag written without a name, the format used by the network protocol since 1.20.2
+func ReadUnnamed(reader io.Reader) (Tag, error) {
+	typeByte, err := readByte(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := Type(typeByte)
+	if typ == TypeEnd {
+		return EndTag{}, nil
+	}
+	return readPayload(reader, typ, 0)
+}
+
+func readNamed(reader io.Reader, depth int) (string, Tag, error) {
 	typeByte, err := readByte(reader)
 	if err != nil {
 		return "", nil, err
@@ -35,51 +63,44 @@ func Read(reader io.Reader) (string, Tag, error) {
 		return "", nil, err
 	}
 
+	tag, err := readPayload(reader, typ, depth)
+	return string(name), tag, err
+}
+
+func readPayload(reader io.Reader, typ Type, depth int) (Tag, error) {
 	switch typ {
 	case TypeByte:
-		tag, err := readByte(reader)
-		return string(name), tag, err
+		return readByte(reader)
 	case TypeShort:
-		tag, err := readShort(reader)
-		return string(name), tag, err
+		return readShort(reader)
 	case TypeInt:
-		tag, err := readInt(reader)
-		return string(name), tag, err
+		return readInt(reader)
 	case TypeLong:
-		tag, err := readLong(reader)
-		return string(name), tag, err
+		return readLong(reader)
 	case TypeFloat:
-		tag, err := readFloat(reader)
-		return string(name), tag, err
+		return readFloat(reader)
 	case TypeDouble:
-		tag, err := readDouble(reader)
-		return string(name), tag, err
+		return readDouble(reader)
 	case TypeByteArray:
-		tag, err := readByteArray(reader)
-		return string(name), tag, err
+		return readByteArray(reader)
 	case TypeString:
-		tag, err := readString(reader)
-		return string(name), tag, err
+		return readString(reader)
 	case TypeList:
-		tag, err := readList(reader)
-		return string(name), tag, err
+		return readList(reader, depth+1)
 	case TypeCompound:
-		tag, err := readCompound(reader)
-		return string(name), tag, err
+		return readCompound(reader, depth+1)
 	case TypeIntArray:
-		tag, err := readIntArray(reader)
-		return string(name), tag, err
+		return readIntArray(reader)
 	case TypeLongArray:
-		tag, err := readLongArray(reader)
-		return string(name), tag, err
+		return readLongArray(reader)
 	default:
-		return string(name), nil, fmt.Errorf("unsupported tag type %v", typ)
+		return nil, fmt.Errorf("unsupported tag type %v", typ)
 	}
 }
 
 func readByte(reader io.Reader) (ByteTag, error) {
 	var buff = make([]byte, 1)
-	if _, err := reader.Read(buff); err != nil {
+	if _, err := io.ReadFull(reader, buff); err != nil {
 		return 0, err
 	}
 	return ByteTag(buff[0]), nil
@@ -87,7 +108,7 @@ func readByte(reader io.Reader) (ByteTag, error) {
 
 func readShort(reader io.Reader) (ShortTag, error) {
 	var buff = make([]byte, 2)
-	if _, err := reader.Read(buff); err != nil {
+	if _, err := io.ReadFull(reader, buff); err != nil {
 		return 0, err
 	}
 	return ShortTag(binary.BigEndian.Uint16(buff)), nil
@@ -95,7 +116,7 @@ func readShort(reader io.Reader) (ShortTag, error) {
 
 func readInt(reader io.Reader) (IntTag, error) {
 	var buff = make([]byte, 4)
-	if _, err := reader.Read(buff); err != nil {
+	if _, err := io.ReadFull(reader, buff); err != nil {
 		return 0, err
 	}
 	return IntTag(binary.BigEndian.Uint32(buff)), nil
@@ -103,7 +124,7 @@ func readInt(reader io.Reader) (IntTag, error) {
 
 func readLong(reader io.Reader) (LongTag, error) {
 	var buff = make([]byte, 8)
-	if _, err := reader.Read(buff); err != nil {
+	if _, err := io.ReadFull(reader, buff); err != nil {
 		return 0, err
 	}
 	return LongTag(binary.BigEndian.Uint64(buff)), nil
@@ -111,7 +132,7 @@ func readLong(reader io.Reader) (LongTag, error) {
 
 func readFloat(reader io.Reader) (FloatTag, error) {
 	var buff = make([]byte, 4)
-	if _, err := reader.Read(buff); err != nil {
+	if _, err := io.ReadFull(reader, buff); err != nil {
 		return 0, err
 	}
 	return FloatTag(math.Float32frombits(binary.BigEndian.Uint32(buff))), nil
@@ -119,14 +140,27 @@ func readFloat(reader io.Reader) (FloatTag, error) {
 
 func readDouble(reader io.Reader) (DoubleTag, error) {
 	var buff = make([]byte, 8)
-	if _, err := reader.Read(buff); err != nil {
+	if _, err := io.ReadFull(reader, buff); err != nil {
 		return 0, err
 	}
 	return DoubleTag(math.Float64frombits(binary.BigEndian.Uint64(buff))), nil
 }
 
+// readLength never preallocates, so a huge declared length can't allocate more than the input holds
+func readLength(reader io.Reader) (int, error) {
+	length, err := readInt(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if length < 0 {
+		return 0, ErrNegativeLength
+	}
+	return int(length), nil
+}
+
 func readByteArray(reader io.Reader) (ByteArrayTag, error) {
-	size, err := readInt(reader)
+	size, err := readLength(reader)
 	if err != nil {
 		return nil, err
 	}
@@ -148,144 +182,68 @@ func readString(reader io.Reader) (StringTag, error) {
 		return "", err
 	}
 
-	var buff = make([]byte, length)
-	if _, err = reader.Read(buff); err != nil {
+	var buff = make([]byte, uint16(length))
+	if _, err = io.ReadFull(reader, buff); err != nil {
 		return "", err
 	}
 	return StringTag(buff), nil
 }
 
-func readList(reader io.Reader) (ListTag, error) {
+func readList(reader io.Reader, depth int) (ListTag, error) {
+	if depth > MaxDepth {
+		return nil, ErrMaxDepth
+	}
+
 	typeByte, err := readByte(reader)
 	if err != nil {
 		return nil, err
 	}
 	typ := Type(typeByte)
 
-	count, err := readInt(reader)
+	count, err := readLength(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	var list ListTag
-	switch typ {
-	case TypeEnd:
-		break
-	case TypeByte:
-		for i := count; i > 0; i-- {
-			tag, err := readByte(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeShort:
-		for i := count; i > 0; i-- {
-			tag, err := readShort(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeInt:
-		for i := count; i > 0; i-- {
-			tag, err := readInt(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeLong:
-		for i := count; i > 0; i-- {
-			tag, err := readLong(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeFloat:
-		for i := count; i > 0; i-- {
-			tag, err := readFloat(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeDouble:
-		for i := count; i > 0; i-- {
-			tag, err := readDouble(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeByteArray:
-		for i := count; i > 0; i-- {
-			tag, err := readByteArray(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeString:
-		for i := count; i > 0; i-- {
-			tag, err := readString(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeList:
-		for i := count; i > 0; i-- {
-			tag, err := readList(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeCompound:
-		for i := count; i > 0; i-- {
-			tag, err := readCompound(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
-		}
-	case TypeIntArray:
-		for i := count; i > 0; i-- {
-			tag, err := readIntArray(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
+	if typ == TypeEnd {
+		if count > 0 {
+			return nil, ErrMissingListType
 		}
-	case TypeLongArray:
-		for i := count; i > 0; i-- {
-			tag, err := readLongArray(reader)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, tag)
+		return nil, nil
+	}
+
+	var list ListTag
+	for i := count; i > 0; i-- {
+		tag, err := readPayload(reader, typ, depth)
+		if err != nil {
+			return nil, err
 		}
-	default:
-		return nil, fmt.Errorf("unsupported tag type %v", typ)
+		list = append(list, tag)
 	}
 	return list, nil
 }
 
-func readCompound(reader io.Reader) (CompoundTag, error) {
+func readCompound(reader io.Reader, depth int) (CompoundTag, error) {
+	if depth > MaxDepth {
+		return nil, ErrMaxDepth
+	}
+
 	var compound = make(CompoundTag)
-	for name, tag, err := Read(reader); tag != nil && tag.Type() != TypeEnd; name, tag, err = Read(reader) {
+	for {
+		name, tag, err := readNamed(reader, depth)
 		if err != nil {
 			return nil, err
 		}
+
+		if tag.Type() == TypeEnd {
+			return compound, nil
+		}
 		compound[name] = tag
 	}
-	return compound, nil
 }
 
 func readIntArray(reader io.Reader) (IntArrayTag, error) {
-	size, err := readInt(reader)
+	size, err := readLength(reader)
 	if err != nil {
 		return nil, err
 	}
@@ -302,7 +260,7 @@ func readIntArray(reader io.Reader) (IntArrayTag, error) {
 }
 
 func readLongArray(reader io.Reader) (LongArrayTag, error) {
-	size, err := readInt(reader)
+	size, err := readLength(reader)
 	if err != nil {
 		return nil, err
 	}
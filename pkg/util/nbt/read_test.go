@@ -0,0 +1,99 @@
+package nbt
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	var buffer bytes.Buffer
+	want := CompoundTag{
+		"name":    StringTag("test"),
+		"numbers": IntArrayTag{1, 2, 3},
+		"list":    ListTag{CompoundTag{"byte": ByteTag(1)}, CompoundTag{"long": LongTag(2)}},
+	}
+	if err := Write(&buffer, "root", want); err != nil {
+		t.Fatal(err)
+	}
+
+	name, got, err := Read(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "root" || len(got.(CompoundTag)) != len(want) {
+		t.Errorf("Read() = %q, %v, want %q, %v", name, got, "root", want)
+	}
+}
+
+func TestReadUnnamed(t *testing.T) {
+	var buffer bytes.Buffer
+	want := CompoundTag{"name": StringTag("test")}
+	if err := WriteUnnamed(&buffer, want); err != nil {
+		t.Fatal(err)
+	}
+
+	if data := buffer.Bytes(); data[0] != byte(TypeCompound) || data[1] != byte(TypeString) {
+		t.Fatalf("WriteUnnamed() = %v, want the root tag without a name", data)
+	}
+
+	got, err := ReadUnnamed(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.(CompoundTag)["name"] != want["name"] || buffer.Len() != 0 {
+		t.Errorf("ReadUnnamed() = %v, want %v", got, want)
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	nested := []byte{byte(TypeList), 0x00, 0x00}
+	for i := 0; i <= MaxDepth; i++ {
+		nested = append(nested, byte(TypeList), 0x00, 0x00, 0x00, 0x01)
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		err   error
+	}{
+		{"negative array length", []byte{byte(TypeByteArray), 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF}, ErrNegativeLength},
+		{"list without type", []byte{byte(TypeList), 0x00, 0x00, byte(TypeEnd), 0x00, 0x00, 0x00, 0x01}, ErrMissingListType},
+		{"nested too deep", nested, ErrMaxDepth},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := Read(bytes.NewReader(test.input)); !errors.Is(err, test.err) {
+				t.Errorf("Read() error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func FuzzRead(f *testing.F) {
+	for _, tag := range []Tag{
+		ByteTag(1), ShortTag(2), IntTag(3), LongTag(4), FloatTag(5), DoubleTag(6),
+		ByteArrayTag{1, 2}, StringTag("test"), IntArrayTag{1, 2}, LongArrayTag{1, 2},
+		ListTag{ListTag{StringTag("a")}, ListTag{}},
+		CompoundTag{"compound": CompoundTag{"list": ListTag{IntTag(1)}}},
+	} {
+		var buffer bytes.Buffer
+		if err := Write(&buffer, "root", tag); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buffer.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		name, tag, err := Read(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything that was read must be writable again
+		var buffer bytes.Buffer
+		if err := Write(&buffer, name, tag); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	})
+}
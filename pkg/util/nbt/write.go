@@ -27,7 +27,18 @@ func Write(writer io.Writer, name string, tag Tag) error {
 			return err
 		}
 	}
+	return writePayload(writer, tag)
+}
+
+// WriteUnnamed writes the tag without a name, the format used by the network protocol since 1.20.2
+func WriteUnnamed(writer io.Writer, tag Tag) error {
+	if err := writeByte(writer, ByteTag(tag.Type())); err != nil {
+		return err
+	}
+	return writePayload(writer, tag)
+}
 
+func writePayload(writer io.Writer, tag Tag) error {
 	switch t := tag.(type) {
 	case EndTag:
 		return nil
@@ -16,7 +16,7 @@ type (
 
 	zlibPool struct {
 		readers sync.Pool
-		writers map[int]sync.Pool
+		writers map[int]*sync.Pool
 	}
 )
 
@@ -56,7 +56,7 @@ func (pool *zlibPool) PutWriter(writer *zlib.Writer, level int) {
 	if levelPool, ok := pool.writers[level]; ok {
 		levelPool.Put(writer)
 	} else {
-		levelPool := sync.Pool{}
+		levelPool := &sync.Pool{}
 		levelPool.Put(writer)
 		pool.writers[level] = levelPool
 	}
@@ -65,6 +65,6 @@ func (pool *zlibPool) PutWriter(writer *zlib.Writer, level int) {
 func NewZlibPool() ZlibPool {
 	return &zlibPool{
 		readers: sync.Pool{},
-		writers: make(map[int]sync.Pool),
+		writers: make(map[int]*sync.Pool),
 	}
 }
@@ -2,6 +2,7 @@ package schematic
 
 import (
 	"errors"
+	"fmt"
 	"github.com/r4g3baby/mcserver/pkg/util/bytes"
 	"github.com/r4g3baby/mcserver/pkg/util/nbt"
 	"io"
@@ -19,25 +20,32 @@ func Read(reader io.Reader) (Schematic, error) {
 	}
 
 	var schem schematic
-	schem.version = int(tag["Version"].(nbt.IntTag))
-	schem.dataVersion = int(tag["DataVersion"].(nbt.IntTag))
+	version, ok := tag["Version"].(nbt.IntTag)
+	if !ok {
+		return nil, missingTag("Version")
+	}
+	schem.version = int(version)
 
-	if meta, ok := tag["Metadata"]; ok {
-		meta := meta.(nbt.CompoundTag)
+	dataVersion, ok := tag["DataVersion"].(nbt.IntTag)
+	if !ok {
+		return nil, missingTag("DataVersion")
+	}
+	schem.dataVersion = int(dataVersion)
 
+	if meta, ok := tag["Metadata"].(nbt.CompoundTag); ok {
 		var name string
-		if mName, ok := meta["Name"]; ok {
-			name = string(mName.(nbt.StringTag))
+		if mName, ok := meta["Name"].(nbt.StringTag); ok {
+			name = string(mName)
 		}
 
 		var author string
-		if mAuthor, ok := meta["Author"]; ok {
-			author = string(mAuthor.(nbt.StringTag))
+		if mAuthor, ok := meta["Author"].(nbt.StringTag); ok {
+			author = string(mAuthor)
 		}
 
 		var date int64
-		if mDate, ok := meta["Date"]; ok {
-			date = int64(mDate.(nbt.LongTag))
+		if mDate, ok := meta["Date"].(nbt.LongTag); ok {
+			date = int64(mDate)
 		}
 
 		schem.metadata = &metadata{
@@ -48,9 +56,28 @@ func Read(reader io.Reader) (Schematic, error) {
 		}
 	}
 
-	schem.width = int(tag["Width"].(nbt.ShortTag))
-	schem.height = int(tag["Height"].(nbt.ShortTag))
-	schem.length = int(tag["Length"].(nbt.ShortTag))
+	// Sizes are unsigned shorts even though nbt only has signed ones
+	for _, size := range []struct {
+		name  string
+		value *int
+	}{{"Width", &schem.width}, {"Height", &schem.height}, {"Length", &schem.length}} {
+		value, ok := tag[size.name].(nbt.ShortTag)
+		if !ok {
+			return nil, missingTag(size.name)
+		}
+		*size.value = int(uint16(value))
+	}
+
+	blocks, ok := tag["BlockData"].(nbt.ByteArrayTag)
+	if !ok {
+		return nil, missingTag("BlockData")
+	}
+
+	// Every block takes at least a byte, checked before allocating anything for them
+	volume := schem.width * schem.height * schem.length
+	if volume > len(blocks) || (volume == 0 && schem.width*schem.height > len(blocks)) {
+		return nil, errors.New("block data is smaller than the schematic")
+	}
 
 	schem.blocks = make([][][]string, schem.width)
 	for x := range schem.blocks {
@@ -60,23 +87,36 @@ func Read(reader io.Reader) (Schematic, error) {
 		}
 	}
 
-	paletteMax := tag["PaletteMax"].(nbt.IntTag)
-	paletteObj := tag["Palette"].(nbt.CompoundTag)
+	paletteMax, ok := tag["PaletteMax"].(nbt.IntTag)
+	if !ok {
+		return nil, missingTag("PaletteMax")
+	}
+	paletteObj, ok := tag["Palette"].(nbt.CompoundTag)
+	if !ok {
+		return nil, missingTag("Palette")
+	}
 	if int(paletteMax) != len(paletteObj) {
 		return nil, errors.New("block palette size does not match expected size")
 	}
 
 	var palette = make(map[int32]string)
 	for block, index := range paletteObj {
-		palette[int32(index.(nbt.IntTag))] = block
+		index, ok := index.(nbt.IntTag)
+		if !ok {
+			return nil, missingTag("Palette." + block)
+		}
+		palette[int32(index)] = block
+	}
+
+	if offset, ok := tag["Offset"].(nbt.IntArrayTag); ok && len(offset) == 3 {
+		schem.offset = [3]int{int(offset[0]), int(offset[1]), int(offset[2])}
 	}
 
-	blocks := tag["BlockData"].(nbt.ByteArrayTag)
 	buff := bytes.NewBuffer(blocks)
-	for i := 0; i < len(blocks); i++ {
+	for i := 0; i < volume; i++ {
 		paletteIndex, err := buff.ReadVarInt()
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		y := i / (schem.width * schem.length)
@@ -87,3 +127,7 @@ func Read(reader io.Reader) (Schematic, error) {
 
 	return &schem, nil
 }
+
+func missingTag(name string) error {
+	return fmt.Errorf("schematic %s tag is missing or has the wrong type", name)
+}
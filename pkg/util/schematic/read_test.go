@@ -0,0 +1,34 @@
+package schematic
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzRead(f *testing.F) {
+	for _, blocks := range [][][][]string{
+		{{{"minecraft:stone"}}},
+		{
+			{{"minecraft:air", "minecraft:stone"}, {"minecraft:dirt", "minecraft:air"}},
+			{{"minecraft:grass_block", "minecraft:air"}, {"minecraft:air", "minecraft:oak_log"}},
+		},
+	} {
+		var buffer bytes.Buffer
+		if err := Write(&buffer, New("test", "fuzz", [3]int{1, 2, 3}, blocks)); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buffer.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		schem, err := Read(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		blocks := schem.GetBlocks()
+		if len(blocks) != schem.GetWidth() {
+			t.Fatalf("Read() has %d columns, want %d", len(blocks), schem.GetWidth())
+		}
+	})
+}
@@ -1,5 +1,10 @@
 package schematic
 
+import "time"
+
+// DataVersion is the data version of the newest supported minecraft version
+const DataVersion = 2586
+
 type (
 	Schematic interface {
 		GetVersion() int
@@ -63,6 +68,7 @@ func (schem *schematic) GetLength() int {
 func (schem *schematic) GetOffset() [3]int {
 	return schem.offset
 }
+
 func (schem *schematic) GetBlocks() [][][]string {
 	return schem.blocks
 }
@@ -82,3 +88,25 @@ func (meta *metadata) GetDate() int64 {
 func (meta *metadata) GetRequiredMods() []string {
 	return meta.requiredMods
 }
+
+// New creates a version 2 schematic, blocks are indexed by [x][y][z]
+func New(name, author string, offset [3]int, blocks [][][]string) Schematic {
+	schem := &schematic{
+		version:     2,
+		dataVersion: DataVersion,
+		metadata: &metadata{
+			name:   name,
+			author: author,
+			date:   time.Now().UnixNano() / int64(time.Millisecond),
+		},
+		offset: offset,
+		blocks: blocks,
+	}
+
+	if schem.width = len(blocks); schem.width > 0 {
+		if schem.height = len(blocks[0]); schem.height > 0 {
+			schem.length = len(blocks[0][0])
+		}
+	}
+	return schem
+}
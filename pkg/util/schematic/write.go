@@ -0,0 +1,56 @@
+package schematic
+
+import (
+	"github.com/r4g3baby/mcserver/pkg/util/bytes"
+	"github.com/r4g3baby/mcserver/pkg/util/nbt"
+	"io"
+)
+
+func Write(writer io.Writer, schem Schematic) error {
+	var palette = make(map[string]int32)
+	blockData := bytes.NewBuffer(nil)
+	for y := 0; y < schem.GetHeight(); y++ {
+		for z := 0; z < schem.GetLength(); z++ {
+			for x := 0; x < schem.GetWidth(); x++ {
+				block := schem.GetBlocks()[x][y][z]
+				index, ok := palette[block]
+				if !ok {
+					index = int32(len(palette))
+					palette[block] = index
+				}
+
+				if err := blockData.WriteVarInt(index); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	paletteObj := make(nbt.CompoundTag, len(palette))
+	for block, index := range palette {
+		paletteObj[block] = nbt.IntTag(index)
+	}
+
+	offset := schem.GetOffset()
+	tag := nbt.CompoundTag{
+		"Version":     nbt.IntTag(schem.GetVersion()),
+		"DataVersion": nbt.IntTag(schem.GetDataVersion()),
+		"Width":       nbt.ShortTag(schem.GetWidth()),
+		"Height":      nbt.ShortTag(schem.GetHeight()),
+		"Length":      nbt.ShortTag(schem.GetLength()),
+		"Offset":      nbt.IntArrayTag{int32(offset[0]), int32(offset[1]), int32(offset[2])},
+		"PaletteMax":  nbt.IntTag(len(palette)),
+		"Palette":     paletteObj,
+		"BlockData":   nbt.ByteArrayTag(blockData.Bytes()),
+	}
+
+	if meta := schem.GetMetadata(); meta != nil {
+		tag["Metadata"] = nbt.CompoundTag{
+			"Name":   nbt.StringTag(meta.GetName()),
+			"Author": nbt.StringTag(meta.GetAuthor()),
+			"Date":   nbt.LongTag(meta.GetDate()),
+		}
+	}
+
+	return nbt.WriteCompressed(writer, "Schematic", tag)
+}
@@ -0,0 +1,8 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package terminal
+
+func makeCbreak(_ int) (func() error, error) {
+	return nil, ErrNotTerminal
+}
@@ -0,0 +1,153 @@
+package terminal
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+var ErrNotTerminal = errors.New("input is not a terminal")
+
+type (
+	// Terminal edits the line being typed itself, anything written through it
+	// is printed above that line instead of breaking it up
+	Terminal interface {
+		io.ReadWriteCloser
+		ReadLine() (string, error)
+	}
+
+	terminal struct {
+		input   *bufio.Reader
+		output  io.Writer
+		prompt  string
+		restore func() error
+
+		mutex   sync.Mutex
+		line    []rune
+		pending []byte
+	}
+)
+
+// Read returns the lines typed in the terminal, each ending with a newline
+func (term *terminal) Read(data []byte) (int, error) {
+	if len(term.pending) == 0 {
+		line, err := term.ReadLine()
+		if err != nil {
+			return 0, err
+		}
+		term.pending = []byte(line + "\n")
+	}
+
+	n := copy(data, term.pending)
+	term.pending = term.pending[n:]
+	return n, nil
+}
+
+// ReadLine blocks until a line is entered, io.EOF is returned when ctrl+d is pressed on an empty line
+func (term *terminal) ReadLine() (string, error) {
+	for {
+		char, _, err := term.input.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		// Escape sequences like the arrow keys aren't supported, so they're skipped entirely
+		if char == '\x1b' {
+			if err := term.skipEscape(); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		term.mutex.Lock()
+		switch {
+		case char == '\r', char == '\n':
+			line := string(term.line)
+			term.line = term.line[:0]
+			_, _ = io.WriteString(term.output, "\n"+term.prompt)
+			term.mutex.Unlock()
+			return line, nil
+		case char == '\x04' && len(term.line) == 0:
+			term.mutex.Unlock()
+			return "", io.EOF
+		case char == '\x7f', char == '\b':
+			if len(term.line) > 0 {
+				term.line = term.line[:len(term.line)-1]
+				term.redraw()
+			}
+		case char == '\x15':
+			term.line = term.line[:0]
+			term.redraw()
+		case char >= ' ':
+			term.line = append(term.line, char)
+			_, _ = io.WriteString(term.output, string(char))
+		}
+		term.mutex.Unlock()
+	}
+}
+
+// skipEscape reads the rest of an escape sequence, CSI and SS3 sequences end with a byte from @ to ~
+func (term *terminal) skipEscape() error {
+	char, _, err := term.input.ReadRune()
+	if err != nil || (char != '[' && char != 'O') {
+		return err
+	}
+
+	for {
+		char, _, err := term.input.ReadRune()
+		if err != nil || (char >= '@' && char <= '~') {
+			return err
+		}
+	}
+}
+
+// Write clears the prompt, prints the data and draws the prompt with the typed line again below it
+func (term *terminal) Write(data []byte) (int, error) {
+	term.mutex.Lock()
+	defer term.mutex.Unlock()
+
+	output := append([]byte("\r\x1b[K"), data...)
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		output = append(output, '\n')
+	}
+	output = append(output, term.prompt...)
+	output = append(output, string(term.line)...)
+
+	if _, err := term.output.Write(output); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (term *terminal) redraw() {
+	_, _ = io.WriteString(term.output, "\r\x1b[K"+term.prompt+string(term.line))
+}
+
+// Close clears the prompt and puts the terminal back in the mode it was in
+func (term *terminal) Close() error {
+	term.mutex.Lock()
+	defer term.mutex.Unlock()
+
+	_, _ = io.WriteString(term.output, "\r\x1b[K")
+	return term.restore()
+}
+
+// NewTerminal takes over the input terminal until it's closed, ErrNotTerminal is returned
+// when the input isn't a terminal, like when it's piped or redirected from a file
+func NewTerminal(input *os.File, output io.Writer, prompt string) (Terminal, error) {
+	restore, err := makeCbreak(int(input.Fd()))
+	if err != nil {
+		return nil, err
+	}
+
+	term := &terminal{
+		input:   bufio.NewReader(input),
+		output:  output,
+		prompt:  prompt,
+		restore: restore,
+	}
+	_, _ = io.WriteString(output, prompt)
+	return term, nil
+}
@@ -0,0 +1,41 @@
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	var output bytes.Buffer
+	term := &terminal{
+		input:  bufio.NewReader(strings.NewReader("ab\x7fc\x1b[Ad\nstop\n\x04")),
+		output: &output,
+		prompt: "> ",
+	}
+
+	for _, want := range []string{"acd", "stop"} {
+		if line, err := term.ReadLine(); err != nil || line != want {
+			t.Errorf("ReadLine() = %q, %v, want %q", line, err, want)
+		}
+	}
+
+	if _, err := term.ReadLine(); err != io.EOF {
+		t.Errorf("ReadLine() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var output bytes.Buffer
+	term := &terminal{output: &output, prompt: "> ", line: []rune("li")}
+
+	if _, err := term.Write([]byte("log line")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := output.String(), "\r\x1b[Klog line\n> li"; got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}